@@ -13,6 +13,7 @@ import (
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/music"
 	"github.com/CarlFlo/DiscordMoneyBot/src/config"
 	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/lotteryManager"
 	"github.com/CarlFlo/DiscordMoneyBot/src/notifyManager"
 	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
 	"github.com/CarlFlo/malm"
@@ -32,6 +33,7 @@ func init() {
 	database.Connect()
 	music.Initialize()
 	notifyManager.Initialize()
+	lotteryManager.Initialize()
 
 	go utils.CheckVersion(CurrentVersion)
 }
@@ -50,6 +52,8 @@ func main() {
 	// Run cleanup code here
 	close(sc)
 	notifyManager.Stop()
+	lotteryManager.Stop()
+	music.SaveSessions()
 	session.Close() // Stops the discord bot
 }
 
@@ -4,9 +4,11 @@ import (
 	"unicode"
 
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands"
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/achievements"
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/daily"
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/dungeon"
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/farming"
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/gambling"
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/mine"
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/work"
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/music"
@@ -77,6 +79,12 @@ func mapValidCommands() {
 	validCommands["profile"] = command{
 		function:           commands.Profile,
 		requiredPermission: enumUser,
+		helpSyntax:         "[bio <text>/color <hex or reset> (optional)]",
+		commandType:        typeGeneral}
+
+	validCommands["achievements"] = command{
+		function:           achievements.Achievements,
+		requiredPermission: enumUser,
 		commandType:        typeGeneral}
 
 	validCommands["farm"] = command{
@@ -89,11 +97,58 @@ func mapValidCommands() {
 		requiredPermission: enumUser,
 		commandType:        typeGeneral}
 
+	validCommands["job"] = command{
+		function:           work.Job,
+		requiredPermission: enumUser,
+		helpSyntax:         "[job name (optional)]",
+		commandType:        typeGeneral}
+
 	validCommands["daily"] = command{
 		function:           daily.Daily,
 		requiredPermission: enumUser,
 		commandType:        typeGeneral}
 
+	validCommands["freeze"] = command{
+		function:           commands.StreakFreeze,
+		requiredPermission: enumUser,
+		helpSyntax:         "[buy (optional)]",
+		commandType:        typeGeneral}
+
+	validCommands["history"] = command{
+		function:           commands.History,
+		requiredPermission: enumUser,
+		commandType:        typeGeneral}
+
+	validCommands["coinflip"] = command{
+		function:           gambling.Coinflip,
+		requiredPermission: enumUser,
+		helpSyntax:         "[bet/all] [heads/tails (optional)]",
+		commandType:        typeGeneral}
+
+	validCommands["slots"] = command{
+		function:           gambling.Slots,
+		requiredPermission: enumUser,
+		helpSyntax:         "[bet/all]",
+		commandType:        typeGeneral}
+
+	validCommands["blackjack"] = command{
+		function:           gambling.Blackjack,
+		requiredPermission: enumUser,
+		helpSyntax:         "[bet/all]",
+		commandType:        typeGeneral}
+
+	validCommands["duel"] = command{
+		function:           gambling.Duel,
+		requiredPermission: enumUser,
+		helpSyntax:         "[@user] [stake/all]",
+		commandType:        typeGeneral}
+
+	validCommands["lottery"] = command{
+		function:           commands.Lottery,
+		requiredPermission: enumUser,
+		helpSyntax:         "[buy [tickets] (optional)]",
+		commandType:        typeGeneral}
+
 	validCommands["dungeon"] = command{
 		function:           dungeon.Dungeon,
 		requiredPermission: enumUser,
@@ -108,7 +163,7 @@ func mapValidCommands() {
 	validCommands["play"] = command{
 		function:           music.PlayMusic,
 		requiredPermission: enumUser,
-		helpSyntax:         "[youtube url/search query]",
+		helpSyntax:         "[youtube url/audio url/file name/search query]",
 		commandType:        typeGeneral}
 
 	validCommands["pause"] = command{
@@ -116,6 +171,11 @@ func mapValidCommands() {
 		requiredPermission: enumUser,
 		commandType:        typeGeneral}
 
+	validCommands["resume"] = command{ // Also resumes the music after a restart
+		function:           music.ResumeMusic,
+		requiredPermission: enumUser,
+		commandType:        typeGeneral}
+
 	validCommands["stop"] = command{ // Will also leave the voice channel
 		function:           music.StopMusic,
 		requiredPermission: enumUser,
@@ -136,6 +196,35 @@ func mapValidCommands() {
 		requiredPermission: enumUser,
 		commandType:        typeGeneral}
 
+	validCommands["nowplaying"] = command{
+		function:           music.NowPlayingMusic,
+		requiredPermission: enumUser,
+		commandType:        typeGeneral}
+
+	validCommands["lyrics"] = command{
+		function:           music.LyricsMusic,
+		requiredPermission: enumUser,
+		helpSyntax:         "[song title (optional)]",
+		commandType:        typeGeneral}
+
+	validCommands["volume"] = command{
+		function:           music.VolumeMusic,
+		requiredPermission: enumUser,
+		helpSyntax:         "[0-200]",
+		commandType:        typeGeneral}
+
+	validCommands["seek"] = command{
+		function:           music.SeekMusic,
+		requiredPermission: enumUser,
+		helpSyntax:         "[mm:ss]",
+		commandType:        typeGeneral}
+
+	validCommands["filter"] = command{
+		function:           music.FilterMusic,
+		requiredPermission: enumUser,
+		helpSyntax:         "[bassboost/nightcore/normalize/off]",
+		commandType:        typeGeneral}
+
 	// Perm User - Economy commands
 	validCommands["balance"] = command{
 		function:           commands.Balance,
@@ -0,0 +1,56 @@
+package achievements
+
+import (
+	"fmt"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Achievements shows the achievements and which of them the user has unlocked
+func Achievements(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	var user database.User
+	user.QueryUserByDiscordID(m.Author.ID)
+
+	unlocked := user.QueryUnlockedAchievements()
+
+	var fields []*discordgo.MessageEmbedField
+	for _, a := range database.GetAchievements() {
+
+		status := fmt.Sprintf("%s Locked", config.CONFIG.Emojis.Failure)
+		if unlocked[a.ID] {
+			status = fmt.Sprintf("%s Unlocked", config.CONFIG.Emojis.Success)
+		}
+
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("%s %s", a.Badge, a.Name),
+			Value:  fmt.Sprintf("%s\nReward: %s %s\n%s", a.Description, utils.HumanReadableNumber(a.Reward), config.CONFIG.Economy.Name, status),
+			Inline: true,
+		})
+	}
+
+	complexMessage := &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{
+		{
+			Type:        discordgo.EmbedTypeRich,
+			Color:       config.CONFIG.Colors.Neutral,
+			Title:       fmt.Sprintf("%s#%s's Achievements", m.Author.Username, m.Author.Discriminator),
+			Description: fmt.Sprintf("You have unlocked %d of %d achievements", len(unlocked), len(database.GetAchievements())),
+			Fields:      fields,
+			Footer: &discordgo.MessageEmbedFooter{
+				Text: "Each achievement is rewarded once and gives a badge on your profile!",
+			},
+			Thumbnail: &discordgo.MessageEmbedThumbnail{
+				URL: fmt.Sprintf("%s#%s", m.Author.AvatarURL("256"), m.Author.ID),
+			},
+		},
+	}}
+
+	if _, err := s.ChannelMessageSendComplex(m.ChannelID, complexMessage); err != nil {
+		malm.Error("Could not send message! %s", err)
+	}
+}
@@ -3,6 +3,7 @@ package daily
 import (
 	"fmt"
 
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/progress"
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
 	"github.com/CarlFlo/DiscordMoneyBot/src/config"
 	"github.com/CarlFlo/DiscordMoneyBot/src/database"
@@ -33,6 +34,10 @@ func Daily(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdI
 			config.CONFIG.Economy.Name,
 			daily.CanDoDailyAt(),
 			daily.ConsecutiveStreaks)
+
+		if notice := database.StreakFreezeNotice(daily.UsedStreakFreezes); len(notice) > 0 {
+			description = fmt.Sprintf("%s\n%s", notice, description)
+		}
 	} else {
 		color = config.CONFIG.Colors.Failure
 		description = fmt.Sprintf("You can get your next daily again %s", daily.CanDoDailyAt())
@@ -70,4 +75,8 @@ func Daily(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdI
 
 	user.Save()
 	daily.Save()
+
+	if ok {
+		progress.Event(s, m.ChannelID, m.Author.ID, database.ActivityDaily, 1)
+	}
 }
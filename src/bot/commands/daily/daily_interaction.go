@@ -4,12 +4,13 @@ import (
 	"fmt"
 
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands"
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/progress"
 	"github.com/CarlFlo/DiscordMoneyBot/src/config"
 	"github.com/CarlFlo/DiscordMoneyBot/src/database"
 	"github.com/bwmarrin/discordgo"
 )
 
-func DoDailyInteraction(authorID string, response *string, author *discordgo.User, me *discordgo.MessageEdit) {
+func DoDailyInteraction(authorID string, response *string, author *discordgo.User, s *discordgo.Session, me *discordgo.MessageEdit) {
 
 	var user database.User
 	user.QueryUserByDiscordID(authorID)
@@ -34,6 +35,10 @@ func DoDailyInteraction(authorID string, response *string, author *discordgo.Use
 			daily.ConsecutiveStreaks,
 			streakPercentage,
 			streakReward)
+
+		if notice := database.StreakFreezeNotice(daily.UsedStreakFreezes); len(notice) > 0 {
+			*response = fmt.Sprintf("%s\n%s", notice, *response)
+		}
 	} else {
 		*response = fmt.Sprintf("%s!\nYou can get your next daily again %s",
 			titleText,
@@ -44,4 +49,8 @@ func DoDailyInteraction(authorID string, response *string, author *discordgo.Use
 
 	user.Save()
 	daily.Save()
+
+	if ok {
+		progress.Event(s, me.Channel, authorID, database.ActivityDaily, 1)
+	}
 }
@@ -20,30 +20,40 @@ func BuyFarmPlotInteraction(discordID string, response *string, s *discordgo.Ses
 	var farm database.Farm
 	farm.QueryUserFarmData(&user)
 
+	if !buyPlotShared(&user, &farm, response) {
+		return
+	}
+
+	discordUser, err := s.User(discordID)
+	if err != nil {
+		malm.Error("Error getting user: %s", err)
+	}
+
+	farm.UpdateInteractionOverview(discordUser, me)
+
+	user.Save()
+	farm.Save()
+}
+
+// buyPlotShared is the shared code for buying a farm plot
+// Returns true if success, else false
+func buyPlotShared(user *database.User, farm *database.Farm, response *string) bool {
+
 	cost := farm.CalcFarmPlotPrice()
 
 	// Valiadate again that the user have enough money
 	if user.Money < uint64(cost) {
 		*response = fmt.Sprintf("You don't have enough money to buy a farm plot!\nYou have: %s %s", user.PrettyPrintMoney(), config.CONFIG.Economy.Name)
-		return
+		return false
 	}
 
 	if farm.HasMaxAmountOfPlots() {
 		*response = fmt.Sprintf("You already have the maximum amount of farm plots!\nYou can only own %d farm plots", config.CONFIG.Farm.MaxPlots)
-		return
+		return false
 	}
 
 	user.DeductMoney(uint64(cost))
 
 	farm.OwnedPlots++
-
-	discordUser, err := s.User(discordID)
-	if err != nil {
-		malm.Error("Error getting user: %s", err)
-	}
-
-	farm.UpdateInteractionOverview(discordUser, me)
-
-	user.Save()
-	farm.Save()
+	return true
 }
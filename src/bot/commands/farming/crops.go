@@ -2,6 +2,7 @@ package farming
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/CarlFlo/DiscordMoneyBot/src/config"
 	"github.com/CarlFlo/DiscordMoneyBot/src/database"
@@ -21,11 +22,10 @@ func farmCrops(s *discordgo.Session, m *discordgo.MessageCreate) {
 	var crops []database.FarmCrop
 	database.DB.Order("id asc").Limit(int(farm.HighestPlantedCropIndex)).Find(&crops)
 
-	description := fmt.Sprintf("Type ``%sfarm [p | plant] <crop>`` to plant a crop!\nAll seeds cost %s ``%s`` %s",
+	description := fmt.Sprintf("Type ``%sfarm [p | plant] <crop>`` to plant a crop!\nCrops in season grow faster and earn %.1fx\n\n%s",
 		config.CONFIG.BotPrefix,
-		config.CONFIG.Emojis.Economy,
-		utils.HumanReadableNumber(config.CONFIG.Farm.CropSeedPrice),
-		config.CONFIG.Economy.Name)
+		config.CONFIG.Farm.InSeasonRewardMultiplier,
+		database.SeasonAndWeatherDescription(time.Now()))
 
 	complexMessage := &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{
 		{
@@ -35,7 +35,7 @@ func farmCrops(s *discordgo.Session, m *discordgo.MessageCreate) {
 			Description: description,
 			Fields:      createFieldsForCrops(&crops),
 			Footer: &discordgo.MessageEmbedFooter{
-				Text: "Crops will perish if not watered every day!\nYou unlock new crops by planting your newest crops on your farm.",
+				Text: "Crops will perish if not watered every day!\nYou unlock new crops by harvesting your newest crop. See farm progress",
 			},
 			Thumbnail: &discordgo.MessageEmbedThumbnail{
 				URL: fmt.Sprintf("%s#%s", m.Author.AvatarURL("256"), m.Author.ID),
@@ -55,10 +55,18 @@ func createFieldsForCrops(fc *[]database.FarmCrop) []*discordgo.MessageEmbedFiel
 
 	var embed []*discordgo.MessageEmbedField
 
+	season := database.SeasonAt(time.Now())
+
 	for _, crop := range *fc {
+
+		name := fmt.Sprintf("%s %s", crop.Emoji, crop.Name)
+		if crop.IsInSeason(season) {
+			name += " (in season)"
+		}
+
 		embed = append(embed, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("%s %s", crop.Emoji, crop.Name),
-			Value:  fmt.Sprintf("Takes %s\nEarns %s", crop.GetDuration(), utils.HumanReadableNumber(crop.HarvestReward)),
+			Name:   name,
+			Value:  fmt.Sprintf("Seeds cost %s\nTakes %s\nEarns %s\nSeasons: %s", utils.HumanReadableNumber(crop.SeedPrice), crop.GetDuration(), utils.HumanReadableNumber(crop.HarvestReward), crop.PreferredSeasonsEmojis()),
 			Inline: true,
 		})
 	}
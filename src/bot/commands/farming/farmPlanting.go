@@ -78,17 +78,17 @@ func FarmPlantInteraction(discordID string, response *string, i *discordgo.Inter
 // Returns true if success, else false
 func farmPlantShared(user *database.User, farm *database.Farm, cropName string, response *string, outputCrop bool) bool {
 
-	if !user.CanAfford(uint64(config.CONFIG.Farm.CropSeedPrice)) {
-		*response = "You don't have enough money to plant a seed!"
-		return false
-	}
-
 	var crop database.FarmCrop
 	if ok := crop.GetCropByName(cropName); !ok {
 		*response = fmt.Sprintf("The crop '%s' is not valid!", cropName)
 		return false
 	}
 
+	if !user.CanAfford(uint64(crop.SeedPrice)) {
+		*response = fmt.Sprintf("You don't have enough money to plant %s %s seeds!", crop.Emoji, crop.Name)
+		return false
+	}
+
 	// Check if the user have unlocked the crop
 	if !farm.HasUserUnlocked(&crop) {
 		*response = "You have not unlocked this crop!"
@@ -100,7 +100,7 @@ func farmPlantShared(user *database.User, farm *database.Farm, cropName string,
 		return false
 	}
 
-	user.DeductMoney(uint64(config.CONFIG.Farm.CropSeedPrice))
+	user.DeductMoney(uint64(crop.SeedPrice))
 
 	fp := &database.FarmPlot{
 		Farm: *farm,
@@ -110,18 +110,8 @@ func farmPlantShared(user *database.User, farm *database.Farm, cropName string,
 	// Create a userFarmPlots entry with the data
 	database.DB.Create(fp)
 
-	// This is to ensure that the crop wont instantly perish once planted if they user haven't watered in a while
-	if farm.MissedWaterDeadline() {
-		farm.ResetLastWatered()
-	}
-
 	if outputCrop {
 		*response = fmt.Sprintf("The crop %s %s was planted!", crop.Emoji, crop.Name)
 	}
-
-	if uint(farm.HighestPlantedCropIndex) == crop.ID {
-		farm.HighestPlantedCropIndex++
-		*response += "\n``You have unlocked a new crop!``"
-	}
 	return true
 }
@@ -13,12 +13,21 @@ var farmCommands = [][]string{
 	{"Get info about available crops", "c", "crop", "crops"},
 	{"Water your crops", "w", "water"},
 	{"Harvest your crops", "h", "harvest"},
+	{"Uproot the crop in a plot", "u", "uproot"},
+	{"Buy upgrades for your farm", "upgrade", "upgrades"},
+	{"See your progress towards unlocking new crops", "progress"},
+	{"Reset your farm for a permanent yield bonus", "prestige"},
+	{"Use the farm shared with your server. Add contribute <amount>, plant <crop>, water, harvest, buy or log", "g", "guild"},
 }
 
 func Farming(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
 
 	// Handle farm arguments
-	if input.ArgsContains(farmCommands[0][1:]) {
+	// The guild farm is checked first, because its subcommands share names with the farm commands
+	if input.NumberOfArgsAreAtleast(1) && isCommand(input.GetArgsLowercase()[0], farmCommands[8][1:]) {
+		guildFarm(s, m, input)
+		return
+	} else if input.ArgsContains(farmCommands[0][1:]) {
 		// User wants to plant some seeds
 		farmPlant(s, m, input)
 		return
@@ -34,6 +43,22 @@ func Farming(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.Cm
 		// Harvest the crops
 		farmHarvestCrops(s, m)
 		return
+	} else if input.ArgsContains(farmCommands[4][1:]) {
+		// Uproot a single plot
+		farmUproot(s, m, input)
+		return
+	} else if input.ArgsContains(farmCommands[5][1:]) {
+		// Buy upgrades
+		farmUpgrade(s, m, input)
+		return
+	} else if input.ArgsContains(farmCommands[6][1:]) {
+		// Show the crop unlock progress
+		farmProgress(s, m)
+		return
+	} else if input.ArgsContains(farmCommands[7][1:]) {
+		// Prestige the farm
+		farmPrestige(s, m, input)
+		return
 	}
 
 	printFarm(s, m, input)
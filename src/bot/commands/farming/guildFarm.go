@@ -0,0 +1,324 @@
+package farming
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+// First index is the help, the rest is the commands. Used after 'farm guild'
+var guildFarmCommands = [][]string{
+	{"Contribute credits to the guild farm", "c", "contribute"},
+	{"Plant a crop on the guild farm", "p", "plant"},
+	{"Water the guild farm", "w", "water"},
+	{"Harvest the guild farm and split the earnings", "h", "harvest"},
+	{"Buy a plot for the guild farm", "b", "buy"},
+	{"See the contributions and what has happened on the guild farm", "l", "log"},
+}
+
+// How many entries of the activity log are shown
+const guildFarmLogLength = 10
+
+// Runs when farm guild is run
+func guildFarm(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	if !config.CONFIG.Farm.GuildFarms {
+		utils.SendMessageFailure(m, "Guild farms are turned off!")
+		return
+	} else if len(m.GuildID) == 0 {
+		utils.SendMessageFailure(m, "The guild farm can only be used in a server!")
+		return
+	}
+
+	var gf database.GuildFarm
+	gf.QueryGuildFarm(m.GuildID)
+
+	var account database.User
+	gf.QueryAccount(&account)
+
+	var farm database.Farm
+	farm.QueryGuildFarmData(&account)
+
+	args := input.GetArgsLowercase()
+	if len(args) < 2 {
+		printGuildFarm(s, m, &gf, &account, &farm)
+		return
+	}
+
+	var member database.User
+	member.QueryUserByDiscordID(m.Author.ID)
+
+	var response string
+	var ok bool
+
+	switch {
+	case isCommand(args[1], guildFarmCommands[0][1:]):
+		if len(args) < 3 {
+			utils.SendMessageFailure(m, fmt.Sprintf("You need to specify how much to contribute. Example: '%sfarm guild contribute 500'", config.CONFIG.BotPrefix))
+			return
+		}
+		ok = guildContributeShared(&gf, &member, &account, args[2], &response)
+	case isCommand(args[1], guildFarmCommands[1][1:]):
+		if len(args) < 3 {
+			utils.SendMessageFailure(m, fmt.Sprintf("You need to specify which crop to plant. Use the command '%sfarm [c | crops]' to see a list of crops.", config.CONFIG.BotPrefix))
+			return
+		}
+		ok = guildFarmActionShared(&gf, &member, &account, &farm, "plant", args[2], &response)
+	case isCommand(args[1], guildFarmCommands[2][1:]):
+		ok = guildFarmActionShared(&gf, &member, &account, &farm, "water", "", &response)
+	case isCommand(args[1], guildFarmCommands[3][1:]):
+		ok = guildFarmActionShared(&gf, &member, &account, &farm, "harvest", "", &response)
+	case isCommand(args[1], guildFarmCommands[4][1:]):
+		ok = guildFarmActionShared(&gf, &member, &account, &farm, "buy", "", &response)
+	case isCommand(args[1], guildFarmCommands[5][1:]):
+		guildFarmLog(s, m, &gf)
+		return
+	default:
+		printGuildFarm(s, m, &gf, &account, &farm)
+		return
+	}
+
+	if ok {
+		utils.SendMessageSuccess(m, response)
+	} else {
+		utils.SendMessageFailure(m, response)
+	}
+
+	member.Save()
+	account.Save()
+	farm.Save()
+}
+
+// isCommand returns true if the argument is one of the commands
+func isCommand(arg string, commands []string) bool {
+	for _, command := range commands {
+		if arg == command {
+			return true
+		}
+	}
+	return false
+}
+
+func printGuildFarm(s *discordgo.Session, m *discordgo.MessageCreate, gf *database.GuildFarm, account *database.User, farm *database.Farm) {
+
+	complexMessage := &discordgo.MessageSend{}
+	farm.CreateGuildFarmOverview(complexMessage, getGuild(s, m.GuildID), gf, account)
+
+	// Sends the message
+	if _, err := s.ChannelMessageSendComplex(m.ChannelID, complexMessage); err != nil {
+		malm.Error("Could not send message! %s", err)
+		return
+	}
+}
+
+// guildFarmLog shows the contributions and the newest entries in the activity log
+func guildFarmLog(s *discordgo.Session, m *discordgo.MessageCreate, gf *database.GuildFarm) {
+
+	contributions := gf.QueryContributions()
+
+	var total uint64
+	for _, c := range contributions {
+		total += c.Amount
+	}
+
+	contributionText := "Nobody has contributed yet"
+	if len(contributions) > 0 {
+		var lines []string
+		for _, c := range contributions {
+			lines = append(lines, fmt.Sprintf("<@%s> %s (%.1f%%)", c.DiscordID, utils.HumanReadableNumber(c.Amount), float64(c.Amount)/float64(total)*100))
+		}
+		contributionText = strings.Join(lines, "\n")
+	}
+
+	activityText := "Nothing has happened yet"
+	if logs := gf.QueryLogs(guildFarmLogLength); len(logs) > 0 {
+		var lines []string
+		for _, l := range logs {
+			lines = append(lines, fmt.Sprintf("<t:%d:R> <@%s> %s", l.CreatedAt.Unix(), l.DiscordID, l.Message))
+		}
+		activityText = strings.Join(lines, "\n")
+	}
+
+	complexMessage := &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{
+		{
+			Type:        discordgo.EmbedTypeRich,
+			Color:       config.CONFIG.Colors.Neutral,
+			Title:       "Guild Farm Log",
+			Description: activityText,
+			Fields: []*discordgo.MessageEmbedField{
+				{
+					Name:  "Contributions",
+					Value: contributionText,
+				},
+			},
+			Footer: &discordgo.MessageEmbedFooter{
+				Text: "The earnings from harvests are split proportionally to the contributions",
+			},
+		},
+	}}
+
+	// Sends the message
+	if _, err := s.ChannelMessageSendComplex(m.ChannelID, complexMessage); err != nil {
+		malm.Error("Could not send message! %s", err)
+		return
+	}
+}
+
+// GuildFarmInteraction handles the buttons and menus on the guild farm message.
+// The action is the custom ID without the 'G' prefix
+func GuildFarmInteraction(action string, guildID string, discordUser *discordgo.User, response *string, i *discordgo.Interaction, s *discordgo.Session, me *discordgo.MessageEdit) {
+
+	if !config.CONFIG.Farm.GuildFarms {
+		*response = "Guild farms are turned off!"
+		return
+	}
+
+	var gf database.GuildFarm
+	gf.QueryGuildFarm(guildID)
+
+	var account database.User
+	gf.QueryAccount(&account)
+
+	var farm database.Farm
+	farm.QueryGuildFarmData(&account)
+
+	var member database.User
+	member.QueryUserByDiscordID(discordUser.ID)
+
+	switch action {
+	case "FH":
+		guildFarmActionShared(&gf, &member, &account, &farm, "harvest", "", response)
+	case "FW":
+		guildFarmActionShared(&gf, &member, &account, &farm, "water", "", response)
+	case "BFP":
+		guildFarmActionShared(&gf, &member, &account, &farm, "buy", "", response)
+	case "FPC":
+		// The value is the name of the crop
+		guildFarmActionShared(&gf, &member, &account, &farm, "plant", i.Data.(discordgo.MessageComponentInteractionData).Values[0], response)
+	case "FPA":
+		// The value is the action and the plot ID. e.g. 'harvest:12'
+		guildFarmActionShared(&gf, &member, &account, &farm, "plot", i.Data.(discordgo.MessageComponentInteractionData).Values[0], response)
+	default:
+		malm.Error("Invalid guild farm interaction: '%s'", action)
+		return
+	}
+
+	member.Save()
+	account.Save()
+	farm.Save()
+
+	// Update the message
+	farm.QueryFarmPlots()
+	farm.UpdateGuildInteractionOverview(getGuild(s, guildID), &gf, &account, me)
+}
+
+// guildContributeShared is the shared code for contributing to the guild farm
+// Returns true if success, else false
+func guildContributeShared(gf *database.GuildFarm, member *database.User, account *database.User, amountString string, response *string) bool {
+
+	amount, err := strconv.ParseUint(amountString, 10, 64)
+	if err != nil || amount == 0 {
+		*response = "The amount has to be a positive number!"
+		return false
+	}
+
+	if !member.CanAfford(amount) {
+		*response = fmt.Sprintf("You don't have enough money!\nYou have: %s %s", member.PrettyPrintMoney(), config.CONFIG.Economy.Name)
+		return false
+	}
+
+	gf.Contribute(member, account, amount)
+
+	*response = fmt.Sprintf("You contributed %s %s to the guild farm. You will get your share of the future harvests!", utils.HumanReadableNumber(amount), config.CONFIG.Economy.Name)
+	return true
+}
+
+// guildFarmActionShared does the action on the guild farm with the contributed credits, and logs it.
+// The earnings from harvests are split between the contributors
+// Returns true if success, else false
+func guildFarmActionShared(gf *database.GuildFarm, member *database.User, account *database.User, farm *database.Farm, action, value string, response *string) bool {
+
+	var ok bool
+	var logMessage string
+
+	switch action {
+	case "plant":
+		var crop database.FarmCrop
+		crop.GetCropByName(value)
+
+		ok = farmPlantShared(account, farm, value, response, true)
+		logMessage = fmt.Sprintf("planted %s %s", crop.Emoji, crop.Name)
+	case "water":
+		ok = waterShared(farm, response, true)
+		logMessage = "watered the crops"
+	case "harvest":
+		ok = harvestShared(account, farm, response)
+		logMessage = "harvested the crops"
+	case "buy":
+		ok = buyPlotShared(account, farm, response)
+		logMessage = "bought a plot"
+	case "plot":
+		plotAction, plotIDString, found := strings.Cut(value, ":")
+		plotID, err := strconv.ParseUint(plotIDString, 10, 64)
+		if !found || err != nil {
+			malm.Error("Invalid farm plot action: '%s'", value)
+			return false
+		}
+
+		farm.QueryFarmPlots()
+		ok = plotActionShared(account, farm, plotAction, uint(plotID), response)
+		logMessage = map[string]string{
+			"harvest": "harvested a plot",
+			"clear":   "cleared a perished plot",
+			"uproot":  "uprooted a plot",
+		}[plotAction]
+	}
+
+	if !ok {
+		return false
+	}
+
+	if farm.SuccessfulHarvest() {
+		logMessage += fmt.Sprintf(" and earned %s %s", utils.HumanReadableNumber(farm.HarvestEarnings), config.CONFIG.Economy.Name)
+		*response += guildPayoutDescription(gf.SplitEarnings(account, member, farm.HarvestEarnings))
+	}
+
+	gf.AddLog(member.DiscordID, logMessage)
+	return true
+}
+
+// guildPayoutDescription lists what each contributor received from the harvest
+func guildPayoutDescription(payouts []database.GuildFarmPayout) string {
+
+	if len(payouts) == 0 {
+		return "\nNobody has contributed, so the earnings were kept by the guild farm"
+	}
+
+	description := "\nThe earnings were split between the contributors:"
+	for _, p := range payouts {
+		description += fmt.Sprintf("\n<@%s> %s %s", p.DiscordID, utils.HumanReadableNumber(p.Amount), config.CONFIG.Economy.Name)
+	}
+	return description
+}
+
+// getGuild returns the guild from the state, or asks Discord for it
+func getGuild(s *discordgo.Session, guildID string) *discordgo.Guild {
+
+	guild, err := s.State.Guild(guildID)
+	if err == nil {
+		return guild
+	}
+
+	if guild, err = s.Guild(guildID); err != nil {
+		malm.Error("Error getting guild: %s", err)
+		return &discordgo.Guild{ID: guildID, Name: "The guild"}
+	}
+	return guild
+}
@@ -2,7 +2,9 @@ package farming
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/progress"
 	"github.com/CarlFlo/DiscordMoneyBot/src/config"
 	"github.com/CarlFlo/DiscordMoneyBot/src/database"
 	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
@@ -19,6 +21,27 @@ func HarvestInteraction(discordID string, response *string, s *discordgo.Session
 	defer farm.Save()
 
 	farm.QueryUserFarmData(&user)
+	harvestsBefore := farm.TotalHarvests
+
+	if harvestShared(&user, &farm, response) {
+		user.Save()
+		progress.Event(s, me.Channel, discordID, database.ActivityHarvest, farm.TotalHarvests-harvestsBefore)
+	}
+
+	discordUser, err := s.User(discordID)
+	if err != nil {
+		malm.Error("Error getting user: %s", err)
+	}
+
+	// Update the message
+	farm.UpdateInteractionOverview(discordUser, me)
+
+}
+
+// harvestShared harvests every plot that is ready and adds the earnings to the user
+// Returns true if anything was harvested or cleared, else false
+func harvestShared(user *database.User, farm *database.Farm, response *string) bool {
+
 	farm.QueryFarmPlots()
 
 	perishedCrops := farm.CropsPerishedCheck()
@@ -27,13 +50,13 @@ func HarvestInteraction(discordID string, response *string, s *discordgo.Session
 
 	if len(result) == 0 && len(perishedCrops) == 0 {
 		*response = "There is currently nothing ready to be harvested!"
-		return
+		return false
 	}
 
 	*response = "Your harvest:\n"
 
 	for _, e := range result {
-		*response += fmt.Sprintf("%s %s\n", e.Emoji, e.Name)
+		*response += fmt.Sprintf("%s %s%s\n", e.Emoji, e.Name, harvestNote(e))
 	}
 
 	for _, name := range perishedCrops {
@@ -41,19 +64,18 @@ func HarvestInteraction(discordID string, response *string, s *discordgo.Session
 	}
 
 	if farm.SuccessfulHarvest() {
-		*response += fmt.Sprintf("\nYou earned %s %s", utils.HumanReadableNumber(farm.HarvestEarnings), config.CONFIG.Economy.Name)
-		user.AddMoney(uint64(farm.HarvestEarnings))
-		user.Save()
+		earner := "You"
+		if farm.IsGuildFarm {
+			earner = "The guild farm"
+		}
+		*response += fmt.Sprintf("\n%s earned %s %s", earner, utils.HumanReadableNumber(farm.HarvestEarnings), config.CONFIG.Economy.Name)
+		creditHarvest(user, farm, farm.HarvestEarnings)
 	}
 
-	discordUser, err := s.User(discordID)
-	if err != nil {
-		malm.Error("Error getting user: %s", err)
+	if farm.UnlockedNewCrop {
+		*response += "\n``You have unlocked a new crop!``"
 	}
-
-	// Update the message
-	farm.UpdateInteractionOverview(discordUser, me)
-
+	return true
 }
 
 func farmHarvestCrops(s *discordgo.Session, m *discordgo.MessageCreate) {
@@ -66,6 +88,7 @@ func farmHarvestCrops(s *discordgo.Session, m *discordgo.MessageCreate) {
 
 	farm.QueryUserFarmData(&user)
 	farm.QueryFarmPlots()
+	harvestsBefore := farm.TotalHarvests
 
 	fields := createFieldsForHarvest(&farm)
 
@@ -98,6 +121,10 @@ func farmHarvestCrops(s *discordgo.Session, m *discordgo.MessageCreate) {
 		malm.Error("Could not send message! %s", err)
 		return
 	}
+
+	if farm.SuccessfulHarvest() {
+		progress.Event(s, m.ChannelID, m.Author.ID, database.ActivityHarvest, farm.TotalHarvests-harvestsBefore)
+	}
 }
 
 // TODO: Make this function output similar to how the interaction does it.
@@ -112,7 +139,7 @@ func createFieldsForHarvest(f *database.Farm) []*discordgo.MessageEmbedField {
 	for _, e := range result {
 		embed = append(embed, &discordgo.MessageEmbedField{
 			Name:   fmt.Sprintf("%s %s", e.Emoji, e.Name),
-			Value:  fmt.Sprintf("You earned %s %s", utils.HumanReadableNumber(e.Earning), config.CONFIG.Economy.Name),
+			Value:  fmt.Sprintf("You earned %s %s%s", utils.HumanReadableNumber(e.Earning), config.CONFIG.Economy.Name, harvestNote(e)),
 			Inline: true,
 		})
 	}
@@ -125,6 +152,14 @@ func createFieldsForHarvest(f *database.Farm) []*discordgo.MessageEmbedField {
 		})
 	}
 
+	if f.UnlockedNewCrop {
+		embed = append(embed, &discordgo.MessageEmbedField{
+			Name:   "New crop",
+			Value:  "You have unlocked a new crop!",
+			Inline: true,
+		})
+	}
+
 	if len(embed) == 0 {
 		embed = append(embed, &discordgo.MessageEmbedField{
 			Name:   "Harvest information",
@@ -135,3 +170,33 @@ func createFieldsForHarvest(f *database.Farm) []*discordgo.MessageEmbedField {
 
 	return embed
 }
+
+// harvestNote explains why the earning of a crop was changed
+func harvestNote(result database.HarvestResult) string {
+	if result.EatenByCrows {
+		return " (eaten by crows!)"
+	}
+
+	var notes []string
+	if result.Fertilized {
+		notes = append(notes, "fertilized")
+	}
+	if result.InSeason {
+		notes = append(notes, "in season")
+	}
+
+	if len(notes) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", strings.Join(notes, ", "))
+}
+
+// creditHarvest adds the earnings to the user. The guild farm account is not a player and its earnings are
+// paid out to the contributors, so they are not counted as earnings of the account
+func creditHarvest(user *database.User, farm *database.Farm, earnings int) {
+	if farm.IsGuildFarm {
+		user.Money += uint64(earnings)
+		return
+	}
+	user.AddMoney(uint64(earnings))
+}
@@ -0,0 +1,134 @@
+package farming
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/progress"
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Runs when farm uproot <plot number> is run
+func farmUproot(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	if !input.NumberOfArgsAre(2) {
+		utils.SendMessageFailure(m, fmt.Sprintf("You need to specify which plot to uproot. Example: '%sfarm uproot 1'", config.CONFIG.BotPrefix))
+		return
+	}
+
+	plotNumber, err := strconv.Atoi(input.GetArgs()[1])
+	if err != nil {
+		utils.SendMessageFailure(m, "The plot number has to be a number!")
+		return
+	}
+
+	var user database.User
+	user.QueryUserByDiscordID(m.Author.ID)
+
+	var farm database.Farm
+	farm.QueryUserFarmData(&user)
+	farm.QueryFarmPlots()
+
+	// The plots are numbered from 1 in the farm overview
+	if plotNumber < 1 || plotNumber > len(farm.Plots) {
+		utils.SendMessageFailure(m, fmt.Sprintf("You don't have a crop planted in plot %d!", plotNumber))
+		return
+	}
+
+	var response string
+	ok := plotActionShared(&user, &farm, "uproot", farm.Plots[plotNumber-1].ID, &response)
+
+	if ok {
+		utils.SendMessageSuccess(m, response)
+	} else {
+		utils.SendMessageFailure(m, response)
+	}
+
+	user.Save()
+	farm.Save()
+}
+
+// FarmPlotActionInteraction harvests or uproots the plot selected in the farm overview menu
+func FarmPlotActionInteraction(discordID string, response *string, i *discordgo.Interaction, s *discordgo.Session, me *discordgo.MessageEdit) {
+
+	// The value is the action and the plot ID. e.g. 'harvest:12'
+	action, plotIDString, found := strings.Cut(i.Data.(discordgo.MessageComponentInteractionData).Values[0], ":")
+	plotID, err := strconv.ParseUint(plotIDString, 10, 64)
+	if !found || err != nil {
+		malm.Error("Invalid farm plot action: '%s'", i.Data.(discordgo.MessageComponentInteractionData).Values[0])
+		return
+	}
+
+	var user database.User
+	user.QueryUserByDiscordID(discordID)
+
+	var farm database.Farm
+	farm.QueryUserFarmData(&user)
+	farm.QueryFarmPlots()
+	harvestsBefore := farm.TotalHarvests
+
+	ok := plotActionShared(&user, &farm, action, uint(plotID), response)
+
+	user.Save()
+	farm.Save()
+
+	if ok && action == "harvest" {
+		progress.Event(s, me.Channel, discordID, database.ActivityHarvest, farm.TotalHarvests-harvestsBefore)
+	}
+
+	discordUser, err := s.User(discordID)
+	if err != nil {
+		malm.Error("Error getting user: %s", err)
+	}
+
+	// Update the message
+	farm.UpdateInteractionOverview(discordUser, me)
+}
+
+// plotActionShared is the shared code for harvesting, clearing or uprooting a single plot
+// Returns true if success, else false
+func plotActionShared(user *database.User, farm *database.Farm, action string, plotID uint, response *string) bool {
+
+	switch action {
+	case "harvest", "clear":
+		result, ok := farm.HarvestPlot(plotID)
+		if !ok {
+			*response = "That crop is not ready to be harvested yet!"
+			return false
+		}
+
+		if result.EatenByCrows {
+			*response = fmt.Sprintf("Crows ate your %s %s before you could harvest it! A scarecrow can keep them away", result.Emoji, result.Name)
+			return true
+		} else if result.Earning == 0 {
+			*response = fmt.Sprintf("You cleared the perished %s %s", config.CONFIG.Emojis.PerishedCrop, result.Name)
+			return true
+		}
+
+		creditHarvest(user, farm, result.Earning)
+		*response = fmt.Sprintf("You harvested %s %s and earned %s %s%s", result.Emoji, result.Name, utils.HumanReadableNumber(result.Earning), config.CONFIG.Economy.Name, harvestNote(result))
+		if farm.UnlockedNewCrop {
+			*response += "\n``You have unlocked a new crop!``"
+		}
+		return true
+
+	case "uproot":
+		crop, ok := farm.UprootPlot(plotID)
+		if !ok {
+			*response = "That plot does not exist anymore!"
+			return false
+		}
+
+		*response = fmt.Sprintf("You uprooted %s %s. The plot is free to use again", crop.Emoji, crop.Name)
+		return true
+	}
+
+	*response = "Invalid action!"
+	return false
+}
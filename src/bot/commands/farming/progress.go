@@ -0,0 +1,92 @@
+package farming
+
+import (
+	"fmt"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Runs when farm progress is run. Shows the unlocked crops and what is needed to unlock the rest
+func farmProgress(s *discordgo.Session, m *discordgo.MessageCreate) {
+
+	var user database.User
+	user.QueryUserByDiscordID(m.Author.ID)
+
+	var farm database.Farm
+	farm.QueryUserFarmData(&user)
+
+	var fields []*discordgo.MessageEmbedField
+
+	for _, p := range farm.GetCropProgress() {
+
+		field := &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("%s %s", p.Crop.Emoji, p.Crop.Name),
+			Value:  fmt.Sprintf("%s Harvested %d times", config.CONFIG.Emojis.Success, p.Harvested),
+			Inline: true,
+		}
+
+		if !p.Unlocked {
+			field.Name = fmt.Sprintf("🔒 %s", p.Crop.Name)
+			field.Value = p.Requirement
+		}
+
+		fields = append(fields, field)
+	}
+
+	description := fmt.Sprintf("Prestige level %d. Your harvests yield %.2fx", farm.PrestigeLevel, farm.PrestigeMultiplier())
+	if farm.HasUnlockedAllCrops() {
+		description += fmt.Sprintf("\nYou have unlocked every crop! Use ``%sfarm prestige`` to reset your farm for a permanent yield bonus", config.CONFIG.BotPrefix)
+	}
+
+	complexMessage := &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{
+		{
+			Type:        discordgo.EmbedTypeRich,
+			Color:       config.CONFIG.Colors.Neutral,
+			Title:       fmt.Sprintf("%s#%s's Farm Progress", m.Author.Username, m.Author.Discriminator),
+			Description: description,
+			Fields:      fields,
+			Footer: &discordgo.MessageEmbedFooter{
+				Text: fmt.Sprintf("Harvest the newest crop you have unlocked %d times to unlock the next one", config.CONFIG.Farm.HarvestsToUnlockNextCrop),
+			},
+			Thumbnail: &discordgo.MessageEmbedThumbnail{
+				URL: fmt.Sprintf("%s#%s", m.Author.AvatarURL("256"), m.Author.ID),
+			},
+		},
+	}}
+
+	if _, err := s.ChannelMessageSendComplex(m.ChannelID, complexMessage); err != nil {
+		malm.Error("Could not send message! %s", err)
+	}
+}
+
+// Runs when farm prestige is run. The user has to confirm it since the farm is reset
+func farmPrestige(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	var user database.User
+	user.QueryUserByDiscordID(m.Author.ID)
+
+	var farm database.Farm
+	farm.QueryUserFarmData(&user)
+
+	if !farm.HasUnlockedAllCrops() {
+		utils.SendMessageFailure(m, fmt.Sprintf("You need to unlock every crop before you can prestige! See ``%sfarm progress``", config.CONFIG.BotPrefix))
+		return
+	}
+
+	nextMultiplier := 1 + float64(farm.PrestigeLevel+1)*config.CONFIG.Farm.PrestigeYieldBonus
+
+	if !input.ArgsContains([]string{"confirm"}) {
+		utils.SendMessageNeutral(m, fmt.Sprintf("Prestiging removes all your crops and plots and locks every crop again, but your harvests will yield %.2fx forever.\nUse ``%sfarm prestige confirm`` to prestige", nextMultiplier, config.CONFIG.BotPrefix))
+		return
+	}
+
+	farm.Prestige()
+	farm.Save()
+
+	utils.SendMessageSuccess(m, fmt.Sprintf("Your farm is now prestige level %d! Your harvests yield %.2fx", farm.PrestigeLevel, farm.PrestigeMultiplier()))
+}
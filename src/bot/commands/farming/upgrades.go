@@ -0,0 +1,123 @@
+package farming
+
+import (
+	"fmt"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Runs when farm upgrade <name> is run. Lists the upgrades if no name is given
+func farmUpgrade(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	if input.NumberOfArgsAre(1) {
+		farmListUpgrades(s, m)
+		return
+	}
+
+	if !input.NumberOfArgsAre(2) {
+		return
+	}
+
+	var user database.User
+	user.QueryUserByDiscordID(m.Author.ID)
+
+	var farm database.Farm
+	farm.QueryUserFarmData(&user)
+
+	var response string
+	ok := buyUpgradeShared(&user, &farm, input.GetArgsLowercase()[1], &response)
+
+	if ok {
+		utils.SendMessageSuccess(m, response)
+	} else {
+		utils.SendMessageFailure(m, response)
+	}
+
+	user.Save()
+	farm.Save()
+}
+
+func farmListUpgrades(s *discordgo.Session, m *discordgo.MessageCreate) {
+
+	var fields []*discordgo.MessageEmbedField
+
+	for _, upgrade := range database.GetFarmUpgrades() {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("%s %s (%s %s)", upgrade.Emoji, upgrade.Name, utils.HumanReadableNumber(upgrade.Price), config.CONFIG.Economy.Name),
+			Value:  upgrade.Description,
+			Inline: false,
+		})
+	}
+
+	complexMessage := &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{
+		{
+			Type:   discordgo.EmbedTypeRich,
+			Color:  config.CONFIG.Colors.Neutral,
+			Title:  "Farm Upgrades",
+			Fields: fields,
+			Footer: &discordgo.MessageEmbedFooter{
+				Text: fmt.Sprintf("Buy an upgrade with '%sfarm upgrade <name>'", config.CONFIG.BotPrefix),
+			},
+		},
+	}}
+
+	if _, err := s.ChannelMessageSendComplex(m.ChannelID, complexMessage); err != nil {
+		malm.Error("Could not send message! %s", err)
+	}
+}
+
+func BuyUpgradeInteraction(discordID string, response *string, i *discordgo.Interaction, s *discordgo.Session, me *discordgo.MessageEdit) {
+
+	upgradeName := i.Data.(discordgo.MessageComponentInteractionData).Values[0]
+
+	var user database.User
+	user.QueryUserByDiscordID(discordID)
+
+	var farm database.Farm
+	farm.QueryUserFarmData(&user)
+
+	buyUpgradeShared(&user, &farm, upgradeName, response)
+
+	user.Save()
+	farm.Save()
+
+	discordUser, err := s.User(discordID)
+	if err != nil {
+		malm.Error("Error getting user: %s", err)
+	}
+
+	// Update the message
+	farm.UpdateInteractionOverview(discordUser, me)
+}
+
+// buyUpgradeShared is the shared code for buying farm upgrades
+// Returns true if success, else false
+func buyUpgradeShared(user *database.User, farm *database.Farm, upgradeName string, response *string) bool {
+
+	upgrade, ok := database.GetFarmUpgradeByName(upgradeName)
+	if !ok {
+		*response = fmt.Sprintf("The upgrade '%s' does not exist!", upgradeName)
+		return false
+	}
+
+	if !farm.CanBuyUpgrade(upgrade) {
+		*response = fmt.Sprintf("Your farm already has the %s!", upgrade.Name)
+		return false
+	}
+
+	if !user.CanAfford(uint64(upgrade.Price)) {
+		*response = fmt.Sprintf("You don't have enough money to buy the %s!", upgrade.Name)
+		return false
+	}
+
+	user.DeductMoney(uint64(upgrade.Price))
+	farm.ApplyUpgrade(upgrade)
+
+	*response = fmt.Sprintf("You bought the %s %s! %s", upgrade.Emoji, upgrade.Name, upgrade.Description)
+	return true
+}
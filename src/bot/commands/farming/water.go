@@ -3,7 +3,6 @@ package farming
 import (
 	"fmt"
 
-	"github.com/CarlFlo/DiscordMoneyBot/src/config"
 	"github.com/CarlFlo/DiscordMoneyBot/src/database"
 	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
 	"github.com/CarlFlo/malm"
@@ -56,12 +55,6 @@ func WaterInteraction(discordID string, response *string, s *discordgo.Session,
 // Returns true if it succeeded, else false
 func waterShared(farm *database.Farm, response *string, printSuccess bool) bool {
 
-	// Check if user can water their plot
-	if !config.CONFIG.Debug.IgnoreWaterCooldown && !farm.CanWater() {
-		*response = fmt.Sprintf("You can't water your farm right now! You can water again %s", farm.CanWaterAt())
-		return false
-	}
-
 	farm.QueryFarmPlots()
 	if len(farm.Plots) == 0 {
 		*response = "You do not have any plots to water. Plant a crop first!"
@@ -70,11 +63,17 @@ func waterShared(farm *database.Farm, response *string, printSuccess bool) bool
 
 	perished := farm.Peek()
 
-	// Decrease the wait time for each crop on the users plots
-	farm.WaterPlots()
+	// Check if user can water any of their plots
+	if !farm.CanWater() {
+		*response = fmt.Sprintf("None of your plots need water right now! You can water again %s", farm.CanWaterAt())
+		return false
+	}
+
+	// Decrease the wait time for each crop that was watered
+	watered := farm.WaterPlots()
 
 	if printSuccess {
-		*response = "You watered your plots and reduced the growth time"
+		*response = fmt.Sprintf("You watered %d plot(s) and reduced the growth time", watered)
 	}
 
 	if perished {
@@ -0,0 +1,402 @@
+package gambling
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+/*
+	Blackjack is played with buttons against the dealer, who stands on 17.
+	The bet is taken when the game starts, so it can't be spent while the game is going.
+	It is kept in the database until the game ends, so it is given back if the bot is stopped during the game.
+	A blackjack pays 3:2 and a win pays 1:1, minus the house edge
+*/
+
+// A card is 0 - 51. The rank is the card modulo 13 and the suit the card divided by 13
+type card int
+
+var (
+	cardRanks = []string{"A", "2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K"}
+	cardSuits = []string{"♠", "♥", "♦", "♣"}
+)
+
+const (
+	blackjackValue    = 21
+	dealerStandsOn    = 17
+	blackjackDeckSize = 52
+)
+
+type blackjack struct {
+	sync.Mutex
+	deck         []card
+	player       []card
+	dealer       []card
+	bet          int                    // Taken from the user when the game started
+	hand         database.BlackjackHand // Holds the bet until the game ends
+	finished     bool
+	thumbnail    *discordgo.MessageEmbedThumbnail
+	channelID    string
+	messageID    string
+	timeoutTimer *time.Timer
+}
+
+// The games being played. The key is the user's discord ID
+var blackjackGames = struct {
+	sync.Mutex
+	playing map[string]*blackjack
+}{playing: make(map[string]*blackjack)}
+
+// Blackjack starts a game of blackjack
+func Blackjack(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	if !input.NumberOfArgsAre(1) {
+		utils.SendMessageFailure(m, fmt.Sprintf("Provide a bet, like '%sblackjack 100'", config.CONFIG.BotPrefix))
+		return
+	}
+
+	blackjackGames.Lock()
+	_, playing := blackjackGames.playing[m.Author.ID]
+	blackjackGames.Unlock()
+
+	if playing {
+		utils.SendMessageFailure(m, "Finish your current game of blackjack first!")
+		return
+	}
+
+	var user database.User
+	user.QueryUserByDiscordID(m.Author.ID)
+
+	var response string
+	bet, ok := parseBet(input.GetArgsLowercase()[0], &user, &response)
+	if !ok || !useCooldown("blackjack", m.Author.ID, &response) {
+		utils.SendMessageFailure(m, response)
+		return
+	}
+
+	game := newBlackjack(rng, bet)
+	if !game.hand.Start(&user, uint64(bet)) {
+		utils.SendMessageFailure(m, "Could not start the game of blackjack!")
+		return
+	}
+
+	game.channelID = m.ChannelID
+	game.thumbnail = &discordgo.MessageEmbedThumbnail{
+		URL: fmt.Sprintf("%s#%s", m.Author.AvatarURL("256"), m.Author.ID),
+	}
+
+	complexMessage := &discordgo.MessageSend{}
+
+	// A blackjack on the deal ends the game right away
+	if handValue(game.player) == blackjackValue || handValue(game.dealer) == blackjackValue {
+		game.finish(&user, &complexMessage.Embeds)
+	} else {
+		complexMessage.Embeds = []*discordgo.MessageEmbed{game.createEmbed("", config.CONFIG.Colors.Neutral)}
+		complexMessage.Components = game.createComponents(&user)
+	}
+
+	msg, err := s.ChannelMessageSendComplex(m.ChannelID, complexMessage)
+	if err != nil {
+		malm.Error("Could not send message! %s", err)
+		if !game.finished {
+			// The game can't be played without the message, so the bet is given back
+			game.hand.Refund()
+		}
+		return
+	}
+
+	if game.finished {
+		return
+	}
+
+	game.Lock()
+	defer game.Unlock()
+
+	game.messageID = msg.ID
+
+	blackjackGames.Lock()
+	blackjackGames.playing[m.Author.ID] = game
+	blackjackGames.Unlock()
+
+	// The player stands if they don't act in time
+	game.timeoutTimer = time.AfterFunc(time.Second*time.Duration(config.CONFIG.Gambling.BlackjackTimeoutSeconds), func() {
+
+		game.Lock()
+		defer game.Unlock()
+
+		if game.finished {
+			return
+		}
+
+		var user database.User
+		user.QueryUserByDiscordID(m.Author.ID)
+
+		me := &discordgo.MessageEdit{Channel: game.channelID, ID: game.messageID, Components: []discordgo.MessageComponent{}}
+		game.finish(&user, &me.Embeds)
+		removeBlackjackGame(m.Author.ID, game)
+
+		if _, err := s.ChannelMessageEditComplex(me); err != nil {
+			malm.Error("Could not edit the blackjack message! %s", err)
+		}
+	})
+}
+
+// BlackjackInteraction is called when the user hits, stands or doubles down from the blackjack message
+func BlackjackInteraction(authorID string, customID string, response *string, me *discordgo.MessageEdit) {
+
+	blackjackGames.Lock()
+	game, ok := blackjackGames.playing[authorID]
+	blackjackGames.Unlock()
+
+	if !ok {
+		*response = "This game has already ended!"
+		return
+	}
+
+	game.Lock()
+	defer game.Unlock()
+
+	if game.finished || game.messageID != me.ID {
+		*response = "This game has already ended!"
+		return
+	}
+
+	var user database.User
+	user.QueryUserByDiscordID(authorID)
+
+	switch customID {
+	case "BJH": // Hit
+		game.player = append(game.player, game.draw())
+		if handValue(game.player) < blackjackValue {
+			me.Embeds = []*discordgo.MessageEmbed{game.createEmbed("", config.CONFIG.Colors.Neutral)}
+			me.Components = game.createComponents(&user)
+			return
+		}
+	case "BJS": // Stand
+	case "BJD": // Double down. The bet is doubled and the player gets exactly one more card
+		if !game.canDoubleDown(&user) || !game.hand.DoubleDown(&user) {
+			*response = "You can't double down right now!"
+			return
+		}
+		game.bet *= 2
+		game.player = append(game.player, game.draw())
+	default:
+		malm.Error("Invalid blackjack action: '%s'", customID)
+		return
+	}
+
+	game.timeoutTimer.Stop()
+	game.finish(&user, &me.Embeds)
+	removeBlackjackGame(authorID, game)
+
+	// Removes the buttons
+	me.Components = []discordgo.MessageComponent{}
+}
+
+// removeBlackjackGame stops tracking the game, unless the user has started a new one
+func removeBlackjackGame(discordID string, game *blackjack) {
+	blackjackGames.Lock()
+	if blackjackGames.playing[discordID] == game {
+		delete(blackjackGames.playing, discordID)
+	}
+	blackjackGames.Unlock()
+}
+
+// newBlackjack shuffles a deck and deals two cards each to the player and the dealer
+func newBlackjack(rng RNG, bet int) *blackjack {
+
+	game := &blackjack{bet: bet}
+
+	// Fisher-Yates shuffle
+	game.deck = make([]card, blackjackDeckSize)
+	for i := range game.deck {
+		game.deck[i] = card(i)
+	}
+	for i := len(game.deck) - 1; i > 0; i-- {
+		j := rng.Intn(i + 1)
+		game.deck[i], game.deck[j] = game.deck[j], game.deck[i]
+	}
+
+	for i := 0; i < 2; i++ {
+		game.player = append(game.player, game.draw())
+		game.dealer = append(game.dealer, game.draw())
+	}
+	return game
+}
+
+// draw takes the top card of the deck
+func (bj *blackjack) draw() card {
+	c := bj.deck[0]
+	bj.deck = bj.deck[1:]
+	return c
+}
+
+// handValue returns the value of the hand. Aces count as 11 unless that would bust the hand
+func handValue(hand []card) int {
+
+	value, aces := 0, 0
+	for _, c := range hand {
+		switch rank := int(c) % 13; {
+		case rank == 0:
+			value += 11
+			aces++
+		case rank >= 9:
+			value += 10
+		default:
+			value += rank + 1
+		}
+	}
+
+	for value > blackjackValue && aces > 0 {
+		value -= 10
+		aces--
+	}
+	return value
+}
+
+func isBlackjack(hand []card) bool {
+	return len(hand) == 2 && handValue(hand) == blackjackValue
+}
+
+func (bj *blackjack) canDoubleDown(user *database.User) bool {
+	return len(bj.player) == 2 && user.CanAfford(uint64(bj.bet))
+}
+
+// dealerPlay draws cards for the dealer until they reach 17
+func (bj *blackjack) dealerPlay() {
+	for handValue(bj.dealer) < dealerStandsOn {
+		bj.dealer = append(bj.dealer, bj.draw())
+	}
+}
+
+// outcome returns the total return of the bet and a text describing how the game ended
+// The dealer has to have played first
+func (bj *blackjack) outcome() (float64, string) {
+
+	player, dealer := handValue(bj.player), handValue(bj.dealer)
+
+	switch {
+	case player > blackjackValue:
+		return 0, "Bust!"
+	case isBlackjack(bj.player) && isBlackjack(bj.dealer):
+		return 1, "Both have blackjack, it's a push"
+	case isBlackjack(bj.player):
+		return 2.5, "Blackjack!"
+	case isBlackjack(bj.dealer):
+		return 0, "The dealer has blackjack"
+	case dealer > blackjackValue:
+		return 2, "The dealer busts!"
+	case player > dealer:
+		return 2, "You beat the dealer!"
+	case player == dealer:
+		return 1, "It's a push"
+	}
+	return 0, "The dealer wins"
+}
+
+// finish lets the dealer play, pays out the bet and creates the final message
+func (bj *blackjack) finish(user *database.User, embeds *[]*discordgo.MessageEmbed) {
+
+	bj.finished = true
+
+	if !bj.hand.End() {
+		*embeds = []*discordgo.MessageEmbed{bj.createEmbed("This game has already ended!", config.CONFIG.Colors.Failure)}
+		return
+	}
+
+	if handValue(bj.player) <= blackjackValue {
+		bj.dealerPlay()
+	}
+
+	multiplier, text := bj.outcome()
+
+	// The bet was already taken, so it is given back when the result is settled
+	difference, ok := settle(user, uint64(bj.bet), bj.bet, payout(bj.bet, multiplier, config.CONFIG.Gambling.BlackjackHouseEdge), "blackjack")
+	if !ok {
+		malm.Error("Could not settle the blackjack game of %s", user.DiscordID)
+	}
+
+	color := config.CONFIG.Colors.Failure
+	if difference > 0 {
+		color = config.CONFIG.Colors.Success
+	}
+
+	result := fmt.Sprintf("%s %s\nYour balance: ``%s`` %s", text, resultText(difference), user.PrettyPrintMoney(), config.CONFIG.Economy.Name)
+	*embeds = []*discordgo.MessageEmbed{bj.createEmbed(result, color)}
+}
+
+// createEmbed shows the hands. The second card of the dealer is hidden until the game is finished
+func (bj *blackjack) createEmbed(result string, color int) *discordgo.MessageEmbed {
+
+	dealerHand := bj.dealer
+	dealerValue := fmt.Sprintf("%d", handValue(bj.dealer))
+	hidden := ""
+	if !bj.finished {
+		dealerHand = bj.dealer[:1]
+		dealerValue = "?"
+		hidden = " ??"
+	}
+
+	description := fmt.Sprintf("Bet: ``%s`` %s\n\n**Your hand** (%d)\n%s\n\n**Dealer's hand** (%s)\n%s%s",
+		utils.HumanReadableNumber(bj.bet),
+		config.CONFIG.Economy.Name,
+		handValue(bj.player),
+		handString(bj.player),
+		dealerValue,
+		handString(dealerHand),
+		hidden)
+
+	if len(result) > 0 {
+		description += "\n\n" + result
+	}
+
+	return &discordgo.MessageEmbed{
+		Type:        discordgo.EmbedTypeRich,
+		Title:       ":black_joker: Blackjack",
+		Description: description,
+		Color:       color,
+		Thumbnail:   bj.thumbnail,
+	}
+}
+
+func (bj *blackjack) createComponents(user *database.User) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Hit",
+					Style:    1,     // Default purple
+					CustomID: "BJH", // 'BJH' is code for 'Blackjack Hit'
+				},
+				discordgo.Button{
+					Label:    "Stand",
+					Style:    2,     // Gray
+					CustomID: "BJS", // 'BJS' is code for 'Blackjack Stand'
+				},
+				discordgo.Button{
+					Label:    fmt.Sprintf("Double down (%s)", utils.HumanReadableNumber(bj.bet)),
+					Style:    3, // Green color style
+					Disabled: !bj.canDoubleDown(user),
+					CustomID: "BJD", // 'BJD' is code for 'Blackjack Double down'
+				},
+			},
+		},
+	}
+}
+
+func handString(hand []card) string {
+
+	var cards []string
+	for _, c := range hand {
+		cards = append(cards, cardRanks[int(c)%13]+cardSuits[int(c)/13])
+	}
+	return strings.Join(cards, " ")
+}
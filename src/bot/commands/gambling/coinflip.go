@@ -0,0 +1,71 @@
+package gambling
+
+import (
+	"fmt"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/bwmarrin/discordgo"
+)
+
+var coinSides = []string{"heads", "tails"}
+
+// Coinflip bets on heads or tails. A win doubles the bet, minus the house edge
+func Coinflip(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	if !input.NumberOfArgsAreAtleast(1) {
+		utils.SendMessageFailure(m, fmt.Sprintf("Provide a bet, like '%scoinflip 100 heads'", config.CONFIG.BotPrefix))
+		return
+	}
+
+	args := input.GetArgsLowercase()
+
+	guess := 0
+	if len(args) > 1 {
+		switch args[1] {
+		case "heads", "h":
+		case "tails", "t":
+			guess = 1
+		default:
+			utils.SendMessageFailure(m, "Pick heads or tails!")
+			return
+		}
+	}
+
+	var user database.User
+	user.QueryUserByDiscordID(m.Author.ID)
+
+	var response string
+	bet, ok := parseBet(args[0], &user, &response)
+	if !ok || !useCooldown("coinflip", m.Author.ID, &response) {
+		utils.SendMessageFailure(m, response)
+		return
+	}
+
+	side := flipCoin(rng)
+
+	multiplier := 0.0
+	if side == guess {
+		multiplier = 2
+	}
+
+	difference, ok := settle(&user, 0, bet, payout(bet, multiplier, config.CONFIG.Gambling.CoinflipHouseEdge), "coinflip")
+	if !ok {
+		utils.SendMessageFailure(m, "You can't afford that bet anymore!")
+		return
+	}
+
+	response = fmt.Sprintf("The coin landed on **%s**! %s\nYour balance: ``%s`` %s", coinSides[side], resultText(difference), user.PrettyPrintMoney(), config.CONFIG.Economy.Name)
+	if difference > 0 {
+		utils.SendMessageSuccess(m, response)
+	} else {
+		utils.SendMessageFailure(m, response)
+	}
+}
+
+// flipCoin returns the index of the side the coin landed on
+func flipCoin(rng RNG) int {
+	return rng.Intn(len(coinSides))
+}
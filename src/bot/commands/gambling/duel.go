@@ -0,0 +1,421 @@
+package gambling
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+/*
+	A duel is a game of rock paper scissors between two users for a stake.
+	The opponent accepts with a button, then both pick in secret. A tie is played again.
+	The stakes are held by the duel in the database, and are returned if it is declined or times out
+*/
+
+var rpsChoices = []string{":rock:", ":roll_of_paper:", ":scissors:"}
+
+type duel struct {
+	sync.Mutex
+	record       database.Duel
+	challengerID string
+	opponentID   string
+	picks        map[string]int // The key is the user's discord ID
+	finished     bool
+	channelID    string
+	messageID    string
+	timeoutTimer *time.Timer
+	deadline     time.Time // A timeout that fires before this was stopped too late and is ignored
+}
+
+// The duels that have not ended. The key is the ID of the duel message
+var duels = struct {
+	sync.Mutex
+	active  map[string]*duel
+	dueling map[string]bool // The discord IDs of the users in a duel, including duels that are being created
+}{active: make(map[string]*duel), dueling: make(map[string]bool)}
+
+// Duel challenges another user to a duel
+func Duel(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	if !input.NumberOfArgsAre(2) || len(m.Mentions) != 1 {
+		utils.SendMessageFailure(m, fmt.Sprintf("Mention who you want to duel and the stake, like '%sduel @user 100'", config.CONFIG.BotPrefix))
+		return
+	}
+
+	if len(m.GuildID) == 0 {
+		utils.SendMessageFailure(m, "Duels can only be started in a server!")
+		return
+	}
+
+	opponent := m.Mentions[0]
+	if opponent.ID == m.Author.ID || opponent.Bot {
+		utils.SendMessageFailure(m, "You can't duel yourself or a bot!")
+		return
+	}
+
+	if !reserveDuelists(m.Author.ID, opponent.ID) {
+		utils.SendMessageFailure(m, "You or your opponent is already in a duel!")
+		return
+	}
+
+	// The users are released if the duel is not started
+	started := false
+	defer func() {
+		if !started {
+			releaseDuelists(m.Author.ID, opponent.ID)
+		}
+	}()
+
+	var challengerUser, opponentUser database.User
+	challengerUser.QueryUserByDiscordID(m.Author.ID)
+	opponentUser.QueryUserByDiscordID(opponent.ID)
+
+	if opponentUser.ID == 0 {
+		utils.SendMessageFailure(m, fmt.Sprintf("%s has not used the bot yet!", opponent.Username))
+		return
+	}
+
+	var response string
+	stake, ok := parseBet(input.GetArgsLowercase()[1], &challengerUser, &response)
+	// The cooldown is started when the duel is accepted
+	if !ok || !checkCooldown("duel", m.Author.ID, &response) {
+		utils.SendMessageFailure(m, response)
+		return
+	}
+
+	if !opponentUser.CanAfford(uint64(stake)) {
+		utils.SendMessageFailure(m, fmt.Sprintf("%s can't afford the stake!", opponent.Username))
+		return
+	}
+
+	d := &duel{
+		challengerID: m.Author.ID,
+		opponentID:   opponent.ID,
+		picks:        make(map[string]int),
+		channelID:    m.ChannelID,
+	}
+
+	if !d.record.CreateDuel(challengerUser.ID, opponentUser.ID, uint64(stake)) {
+		utils.SendMessageFailure(m, "Could not create the duel!")
+		return
+	}
+
+	complexMessage := &discordgo.MessageSend{
+		Content: fmt.Sprintf("<@%s>", opponent.ID),
+		Embeds: []*discordgo.MessageEmbed{
+			d.createEmbed(fmt.Sprintf("<@%s> challenges <@%s> to rock paper scissors!\n\nAccept <t:%d:R>", d.challengerID, d.opponentID, time.Now().Add(duelTimeout()).Unix()), config.CONFIG.Colors.Neutral),
+		},
+		Components: createDuelChallengeComponents(),
+	}
+
+	msg, err := s.ChannelMessageSendComplex(m.ChannelID, complexMessage)
+	if err != nil {
+		malm.Error("Could not send message! %s", err)
+		d.record.Refund()
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.messageID = msg.ID
+
+	duels.Lock()
+	duels.active[d.messageID] = d
+	duels.Unlock()
+
+	d.deadline = time.Now().Add(duelTimeout())
+	d.timeoutTimer = time.AfterFunc(duelTimeout(), func() { d.timeout(s) })
+	started = true
+}
+
+// DuelInteraction is called when a user accepts, declines or picks from the duel message
+func DuelInteraction(interactor *discordgo.User, customID string, response *string, me *discordgo.MessageEdit) {
+
+	duels.Lock()
+	d, ok := duels.active[me.ID]
+	duels.Unlock()
+
+	if !ok {
+		*response = "This duel has already ended!"
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	if d.finished {
+		*response = "This duel has already ended!"
+		return
+	}
+
+	if interactor.ID != d.challengerID && interactor.ID != d.opponentID {
+		*response = "You are not part of this duel!"
+		return
+	}
+
+	switch customID {
+	case "DUA": // Accept
+		if d.record.Accepted {
+			*response = "The duel has already been accepted!"
+			return
+		}
+
+		if interactor.ID != d.opponentID {
+			*response = "Only the challenged user can accept the duel!"
+			return
+		}
+
+		if !d.record.Accept() {
+			*response = "You can't afford the stake!"
+			return
+		}
+
+		startCooldown("duel", d.challengerID)
+
+		// The users get a new timeframe to pick in
+		d.restartTimeout()
+		me.Embeds = []*discordgo.MessageEmbed{d.createPickEmbed("")}
+		me.Components = createDuelPickComponents()
+	case "DUD": // Decline, or cancel by the challenger
+		if d.record.Accepted {
+			*response = "The duel has already been accepted!"
+			return
+		}
+
+		d.end()
+		if !d.record.Refund() {
+			*response = "This duel has already ended!"
+			return
+		}
+
+		text := fmt.Sprintf("<@%s> declined the duel. The stake was returned", d.opponentID)
+		if interactor.ID == d.challengerID {
+			text = fmt.Sprintf("<@%s> cancelled the duel. The stake was returned", d.challengerID)
+		}
+		me.Embeds = []*discordgo.MessageEmbed{d.createEmbed(text, config.CONFIG.Colors.Failure)}
+		me.Components = []discordgo.MessageComponent{}
+	case "DUP0", "DUP1", "DUP2": // Pick
+		if !d.record.Accepted {
+			*response = "The duel has not been accepted yet!"
+			return
+		}
+
+		if _, picked := d.picks[interactor.ID]; picked {
+			*response = "You have already picked!"
+			return
+		}
+
+		pick := int(customID[3] - '0')
+		d.picks[interactor.ID] = pick
+		*response = fmt.Sprintf("You picked %s", rpsChoices[pick])
+
+		if len(d.picks) < 2 {
+			me.Embeds = []*discordgo.MessageEmbed{d.createPickEmbed("")}
+			return
+		}
+
+		d.resolve(me)
+	default:
+		malm.Error("Invalid duel action: '%s'", customID)
+	}
+}
+
+// resolve pays out the duel when both users have picked. A tie is played again
+func (d *duel) resolve(me *discordgo.MessageEdit) {
+
+	challengerPick, opponentPick := d.picks[d.challengerID], d.picks[d.opponentID]
+	picked := fmt.Sprintf("<@%s> picked %s and <@%s> picked %s", d.challengerID, rpsChoices[challengerPick], d.opponentID, rpsChoices[opponentPick])
+
+	winner := rpsWinner(challengerPick, opponentPick)
+	if winner == 0 {
+		d.picks = make(map[string]int)
+		// The users get a new timeframe to pick in
+		d.restartTimeout()
+		me.Embeds = []*discordgo.MessageEmbed{d.createPickEmbed(picked + ". It's a tie, pick again!")}
+		return
+	}
+
+	winnerID, winnerUserID := d.challengerID, d.record.ChallengerID
+	if winner == 2 {
+		winnerID, winnerUserID = d.opponentID, d.record.OpponentID
+	}
+
+	d.end()
+	if !d.record.Payout(winnerUserID) {
+		malm.Error("Could not pay out the duel %d", d.record.ID)
+		if d.record.Refund() {
+			me.Embeds = []*discordgo.MessageEmbed{d.createEmbed("Something went wrong. The stakes were returned", config.CONFIG.Colors.Failure)}
+			me.Components = []discordgo.MessageComponent{}
+		}
+		return
+	}
+
+	text := fmt.Sprintf("%s.\n\n<@%s> wins ``%s`` %s!", picked, winnerID, utils.HumanReadableNumber(d.record.Stake*2), config.CONFIG.Economy.Name)
+	me.Embeds = []*discordgo.MessageEmbed{d.createEmbed(text, config.CONFIG.Colors.Success)}
+	me.Components = []discordgo.MessageComponent{}
+}
+
+// timeout returns the stakes if the duel was not accepted or finished in time
+func (d *duel) timeout(s *discordgo.Session) {
+
+	d.Lock()
+	defer d.Unlock()
+
+	// The timer was restarted while this was waiting for the lock
+	if d.finished || time.Now().Before(d.deadline) {
+		return
+	}
+
+	d.end()
+	if !d.record.Refund() {
+		return
+	}
+
+	me := &discordgo.MessageEdit{
+		Channel:    d.channelID,
+		ID:         d.messageID,
+		Embeds:     []*discordgo.MessageEmbed{d.createEmbed("The duel timed out. The stakes were returned", config.CONFIG.Colors.Failure)},
+		Components: []discordgo.MessageComponent{},
+	}
+
+	if _, err := s.ChannelMessageEditComplex(me); err != nil {
+		malm.Error("Could not edit the duel message! %s", err)
+	}
+}
+
+// restartTimeout gives the users a new timeframe. The duel has to be locked
+func (d *duel) restartTimeout() {
+
+	d.deadline = time.Now().Add(duelTimeout())
+	d.timeoutTimer.Reset(duelTimeout())
+}
+
+// end stops tracking the duel. The stakes have to be paid out or refunded after
+func (d *duel) end() {
+
+	d.finished = true
+	d.timeoutTimer.Stop()
+
+	duels.Lock()
+	delete(duels.active, d.messageID)
+	duels.Unlock()
+
+	releaseDuelists(d.challengerID, d.opponentID)
+}
+
+// reserveDuelists marks the users as dueling. The check and the marking is done together,
+// so two duels can't be started with the same user at the same time
+// Returns false if any of the users already is in a duel
+func reserveDuelists(discordIDs ...string) bool {
+
+	duels.Lock()
+	defer duels.Unlock()
+
+	for _, discordID := range discordIDs {
+		if duels.dueling[discordID] {
+			return false
+		}
+	}
+
+	for _, discordID := range discordIDs {
+		duels.dueling[discordID] = true
+	}
+	return true
+}
+
+// releaseDuelists lets the users start or accept new duels
+func releaseDuelists(discordIDs ...string) {
+
+	duels.Lock()
+	defer duels.Unlock()
+
+	for _, discordID := range discordIDs {
+		delete(duels.dueling, discordID)
+	}
+}
+
+// rpsWinner returns 1 if the first pick wins, 2 if the second pick wins and 0 for a tie
+// Each choice is beaten by the next one, and the last one by the first
+func rpsWinner(first, second int) int {
+
+	switch {
+	case first == second:
+		return 0
+	case (first+1)%len(rpsChoices) == second:
+		return 2
+	}
+	return 1
+}
+
+func (d *duel) createPickEmbed(text string) *discordgo.MessageEmbed {
+
+	status := func(discordID string) string {
+		if _, picked := d.picks[discordID]; picked {
+			return config.CONFIG.Emojis.Success
+		}
+		return ":hourglass:"
+	}
+
+	description := fmt.Sprintf("%s <@%s>\n%s <@%s>\n\nPick <t:%d:R>. The picks are secret until both have picked",
+		status(d.challengerID), d.challengerID, status(d.opponentID), d.opponentID, time.Now().Add(duelTimeout()).Unix())
+
+	if len(text) > 0 {
+		description = text + "\n\n" + description
+	}
+	return d.createEmbed(description, config.CONFIG.Colors.Neutral)
+}
+
+func (d *duel) createEmbed(description string, color int) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Type:        discordgo.EmbedTypeRich,
+		Title:       fmt.Sprintf(":crossed_swords: Duel for %s %s", utils.HumanReadableNumber(d.record.Stake), config.CONFIG.Economy.Name),
+		Description: description,
+		Color:       color,
+	}
+}
+
+func createDuelChallengeComponents() []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Accept",
+					Style:    3,     // Green color style
+					CustomID: "DUA", // 'DUA' is code for 'Duel Accept'
+				},
+				discordgo.Button{
+					Label:    "Decline",
+					Style:    4,     // Red color style
+					CustomID: "DUD", // 'DUD' is code for 'Duel Decline'
+				},
+			},
+		},
+	}
+}
+
+// The custom ID of each button is 'DUP' followed by the index of the choice
+func createDuelPickComponents() []discordgo.MessageComponent {
+
+	var buttons []discordgo.MessageComponent
+	for i, choice := range []string{"Rock", "Paper", "Scissors"} {
+		buttons = append(buttons, discordgo.Button{
+			Label:    choice,
+			Style:    1,                       // Default purple
+			CustomID: fmt.Sprintf("DUP%d", i), // 'DUP' is code for 'Duel Pick'
+		})
+	}
+
+	return []discordgo.MessageComponent{discordgo.ActionsRow{Components: buttons}}
+}
+
+func duelTimeout() time.Duration {
+	return time.Second * time.Duration(config.CONFIG.Gambling.DuelTimeoutSeconds)
+}
@@ -0,0 +1,145 @@
+package gambling
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+)
+
+/*
+	The games let the users bet their money. Each game has its own house edge and cooldown.
+	Every result is recorded in the money history of the user
+*/
+
+// RNG is where the games get their randomness from. The tests replace it with a predictable one
+type RNG interface {
+	Intn(n int) int
+}
+
+type defaultRNG struct{}
+
+func (defaultRNG) Intn(n int) int {
+	return rand.Intn(n)
+}
+
+var rng RNG = defaultRNG{}
+
+// When each user can play each game again. The key is the game followed by the user's discord ID
+var cooldowns = struct {
+	sync.Mutex
+	until map[string]time.Time
+}{until: make(map[string]time.Time)}
+
+// parseBet parses the bet and checks that the user can afford it. 'all' bets as much as possible
+// Returns false if the bet is not valid, with the reason in the response
+func parseBet(arg string, user *database.User, response *string) (int, bool) {
+
+	minBet, maxBet := config.CONFIG.Gambling.MinBet, config.CONFIG.Gambling.MaxBet
+
+	var bet int
+	if arg == "all" {
+		bet = maxBet
+		if user.Money < uint64(maxBet) {
+			bet = int(user.Money)
+		}
+	} else {
+		var err error
+		if bet, err = strconv.Atoi(arg); err != nil {
+			*response = fmt.Sprintf("'%s' is not a valid bet!", arg)
+			return 0, false
+		}
+	}
+
+	if bet < minBet || bet > maxBet {
+		*response = fmt.Sprintf("The bet has to be between ``%s`` and ``%s`` %s!", utils.HumanReadableNumber(minBet), utils.HumanReadableNumber(maxBet), config.CONFIG.Economy.Name)
+		return 0, false
+	}
+
+	if !user.CanAfford(uint64(bet)) {
+		*response = fmt.Sprintf("You are lacking ``%s`` %s for this bet.\nYour balance: ``%s`` %s", utils.HumanReadableNumber(uint64(bet)-user.Money), config.CONFIG.Economy.Name, user.PrettyPrintMoney(), config.CONFIG.Economy.Name)
+		return 0, false
+	}
+
+	return bet, true
+}
+
+// useCooldown starts the cooldown of the game for the user
+// Returns false if the user is still on cooldown, with the reason in the response
+func useCooldown(game string, discordID string, response *string) bool {
+
+	cooldowns.Lock()
+	defer cooldowns.Unlock()
+
+	if !cooldownOver(game, discordID, response) {
+		return false
+	}
+
+	cooldowns.until[game+discordID] = time.Now().Add(time.Second * time.Duration(config.CONFIG.Gambling.CooldownSeconds))
+	return true
+}
+
+// checkCooldown returns false if the user is still on cooldown, with the reason in the response
+// The cooldown is not started, for games that can be cancelled before they are played
+func checkCooldown(game string, discordID string, response *string) bool {
+
+	cooldowns.Lock()
+	defer cooldowns.Unlock()
+
+	return cooldownOver(game, discordID, response)
+}
+
+// startCooldown starts the cooldown of the game for the user
+func startCooldown(game string, discordID string) {
+
+	cooldowns.Lock()
+	defer cooldowns.Unlock()
+
+	cooldowns.until[game+discordID] = time.Now().Add(time.Second * time.Duration(config.CONFIG.Gambling.CooldownSeconds))
+}
+
+// cooldownOver returns false if the user is still on cooldown. The cooldowns have to be locked
+func cooldownOver(game string, discordID string, response *string) bool {
+
+	if until, ok := cooldowns.until[game+discordID]; ok && time.Now().Before(until) {
+		*response = fmt.Sprintf("You can play %s again <t:%d:R>", game, until.Unix())
+		return false
+	}
+	return true
+}
+
+// payout returns how much the player gets back for the bet. The multiplier is the total return, so 2 doubles the bet
+// The house keeps its edge of the winnings
+func payout(bet int, multiplier float64, houseEdge float64) int {
+
+	if multiplier <= 1 {
+		return int(float64(bet) * multiplier)
+	}
+
+	winnings := float64(bet) * (multiplier - 1) * (1 - houseEdge)
+	return bet + int(winnings)
+}
+
+// settle gives or takes the difference between the payout and the bet, and records it in the money history
+// held is the part of the bet already taken from the user. Returns the difference, and false if the user can't afford the bet
+func settle(user *database.User, held uint64, bet int, returned int, game string) (int, bool) {
+
+	difference := returned - bet
+	return difference, user.SettleBet(held, int64(difference), game)
+}
+
+// resultText describes the difference between the payout and the bet
+func resultText(difference int) string {
+
+	if difference > 0 {
+		return fmt.Sprintf("You won ``%s`` %s!", utils.HumanReadableNumber(difference), config.CONFIG.Economy.Name)
+	} else if difference < 0 {
+		return fmt.Sprintf("You lost ``%s`` %s", utils.HumanReadableNumber(-difference), config.CONFIG.Economy.Name)
+	}
+	return "You got your bet back"
+}
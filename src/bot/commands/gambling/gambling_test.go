@@ -0,0 +1,171 @@
+package gambling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+// sequenceRNG returns the numbers in order, wrapped to fit below n
+type sequenceRNG struct {
+	numbers []int
+	next    int
+}
+
+func (r *sequenceRNG) Intn(n int) int {
+	number := r.numbers[r.next%len(r.numbers)] % n
+	r.next++
+	return number
+}
+
+func TestPayout(t *testing.T) {
+
+	test.Validate(t, payout(100, 0, 0.05), 0, "A loss should return nothing")
+	test.Validate(t, payout(100, 0.5, 0.05), 50, "The house edge should not affect returns below the bet")
+	test.Validate(t, payout(100, 1, 0.05), 100, "A push should return the bet")
+	test.Validate(t, payout(100, 2, 0), 200, "A win without a house edge should double the bet")
+	test.Validate(t, payout(100, 2, 0.05), 195, "The house should keep its edge of the winnings")
+	test.Validate(t, payout(100, 2.5, 0.1), 235, "The house should keep its edge of the winnings")
+}
+
+func TestFlipCoin(t *testing.T) {
+
+	test.Validate(t, flipCoin(&sequenceRNG{numbers: []int{0}}), 0, "The coin should land on heads")
+	test.Validate(t, flipCoin(&sequenceRNG{numbers: []int{1}}), 1, "The coin should land on tails")
+}
+
+func TestSpinSlots(t *testing.T) {
+
+	// The weights are 7, 5, 4, 2 and 1, so 0 is the first cherry, 7 the first lemon and 18 the seven
+	reels := spinSlots(&sequenceRNG{numbers: []int{0, 7, 18}})
+	test.Validate(t, reels, [slotReels]int{0, 1, 4}, "The reels landed on the wrong symbols")
+
+	test.Validate(t, slotsMultiplier([slotReels]int{4, 4, 4}), 200.0, "Three sevens should pay the most")
+	test.Validate(t, slotsMultiplier([slotReels]int{2, 1, 2}), slotsPairMultiplier, "A pair should return part of the bet")
+	test.Validate(t, slotsMultiplier([slotReels]int{0, 1, 2}), 0.0, "No matching symbols should lose the bet")
+}
+
+func TestSlotsExpectedReturn(t *testing.T) {
+
+	totalWeight := 0
+	for _, symbol := range slotSymbols {
+		totalWeight += symbol.weight
+	}
+
+	// Every combination of the reels, weighted by how likely it is
+	expected := 0.0
+	for a, first := range slotSymbols {
+		for b, second := range slotSymbols {
+			for c, third := range slotSymbols {
+				chance := float64(first.weight*second.weight*third.weight) / float64(totalWeight*totalWeight*totalWeight)
+				expected += chance * slotsMultiplier([slotReels]int{a, b, c})
+			}
+		}
+	}
+
+	if expected >= 1 || expected < 0.9 {
+		t.Errorf("Expected the slots to return between 90%% and 100%% of the bets, got %.2f%%", expected*100)
+	}
+}
+
+func TestHandValue(t *testing.T) {
+
+	// The rank is the card modulo 13. 0 is an ace, 9 - 12 are tens and faces
+	test.Validate(t, handValue([]card{0, 12}), 21, "An ace and a king should be 21")
+	test.Validate(t, handValue([]card{0, 0, 8}), 21, "Only one ace should count as 11")
+	test.Validate(t, handValue([]card{0, 9, 4}), 16, "The ace should count as 1 when 11 busts")
+	test.Validate(t, handValue([]card{9, 10, 1}), 22, "The hand should bust")
+	test.Validate(t, isBlackjack([]card{13, 11}), true, "An ace and a queen should be blackjack")
+	test.Validate(t, isBlackjack([]card{9, 5, 4}), false, "Three cards should not be blackjack")
+}
+
+func TestBlackjackOutcome(t *testing.T) {
+
+	cases := []struct {
+		player     []card
+		dealer     []card
+		multiplier float64
+	}{
+		{[]card{9, 10, 1}, []card{9, 6}, 0},   // Player busts
+		{[]card{0, 12}, []card{9, 8}, 2.5},    // Player blackjack
+		{[]card{0, 12}, []card{13, 11}, 1},    // Both blackjack
+		{[]card{9, 8}, []card{13, 11}, 0},     // Dealer blackjack
+		{[]card{9, 8}, []card{9, 5, 9}, 2},    // Dealer busts
+		{[]card{9, 8}, []card{9, 6}, 2},       // Player higher
+		{[]card{9, 7}, []card{9, 6, 13}, 1},   // Push
+		{[]card{9, 5, 1}, []card{9, 8}, 0},    // Dealer higher
+		{[]card{5, 4, 3, 1}, []card{9, 5}, 2}, // Five cards, higher than the dealer
+	}
+
+	for i, c := range cases {
+		game := &blackjack{player: c.player, dealer: c.dealer}
+		multiplier, _ := game.outcome()
+		if multiplier != c.multiplier {
+			t.Errorf("Case %d: expected the multiplier %.1f, got %.1f", i, c.multiplier, multiplier)
+		}
+	}
+}
+
+func TestDealerPlay(t *testing.T) {
+
+	game := &blackjack{dealer: []card{9, 1}, deck: []card{2, 3, 4}}
+	game.dealerPlay()
+	test.Validate(t, handValue(game.dealer), 19, "The dealer should draw until 17")
+	test.Validate(t, len(game.deck), 1, "The dealer should draw two cards")
+
+	game = &blackjack{dealer: []card{9, 6}, deck: []card{2}}
+	game.dealerPlay()
+	test.Validate(t, len(game.dealer), 2, "The dealer should stand on 17")
+}
+
+func TestNewBlackjack(t *testing.T) {
+
+	game := newBlackjack(&sequenceRNG{numbers: []int{3, 17, 42, 8, 29}}, 100)
+
+	test.Validate(t, len(game.player), 2, "The player should be dealt two cards")
+	test.Validate(t, len(game.dealer), 2, "The dealer should be dealt two cards")
+	test.Validate(t, len(game.deck), blackjackDeckSize-4, "The dealt cards should be taken from the deck")
+
+	seen := map[card]bool{}
+	for _, c := range append(append(game.deck, game.player...), game.dealer...) {
+		if seen[c] {
+			t.Errorf("The card %d is in the game twice", c)
+		}
+		seen[c] = true
+	}
+	test.Validate(t, len(seen), blackjackDeckSize, "Every card should be in the game once")
+}
+
+func TestRPSWinner(t *testing.T) {
+
+	rock, paper, scissors := 0, 1, 2
+
+	test.Validate(t, rpsWinner(rock, rock), 0, "The same picks should tie")
+	test.Validate(t, rpsWinner(paper, rock), 1, "Paper should beat rock")
+	test.Validate(t, rpsWinner(rock, paper), 2, "Paper should beat rock")
+	test.Validate(t, rpsWinner(scissors, paper), 1, "Scissors should beat paper")
+	test.Validate(t, rpsWinner(rock, scissors), 1, "Rock should beat scissors")
+	test.Validate(t, rpsWinner(scissors, rock), 2, "Rock should beat scissors")
+}
+
+func TestReserveDuelists(t *testing.T) {
+
+	test.Validate(t, reserveDuelists("a", "b"), true, "Users not in a duel should be reserved")
+	test.Validate(t, reserveDuelists("b", "c"), false, "A user already in a duel should not be reserved again")
+	test.Validate(t, reserveDuelists("c", "d"), true, "A failed reservation should not reserve anyone")
+
+	releaseDuelists("a", "b")
+	test.Validate(t, reserveDuelists("b", "a"), true, "Released users should be reserved again")
+
+	releaseDuelists("a", "b", "c", "d")
+}
+
+func TestStaleDuelTimeout(t *testing.T) {
+
+	// The timer was restarted after it fired, so the duel should keep going
+	d := &duel{deadline: time.Now().Add(time.Minute)}
+	d.timeout(nil)
+
+	test.Validate(t, d.finished, false, "A stale timeout should not end the duel")
+}
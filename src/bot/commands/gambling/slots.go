@@ -0,0 +1,113 @@
+package gambling
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/bwmarrin/discordgo"
+)
+
+type slotSymbol struct {
+	emoji      string
+	weight     int     // How often the symbol lands, compared to the other symbols
+	multiplier float64 // The total return for three of the symbol
+}
+
+// Rarer symbols pay more. The odds give back about 98% of the bets before the house edge
+var slotSymbols = []slotSymbol{
+	{emoji: ":cherries:", weight: 7, multiplier: 5},
+	{emoji: ":lemon:", weight: 5, multiplier: 10},
+	{emoji: ":bell:", weight: 4, multiplier: 20},
+	{emoji: ":star:", weight: 2, multiplier: 50},
+	{emoji: ":seven:", weight: 1, multiplier: 200},
+}
+
+// Two of the same symbol gives back this much of the bet
+const slotsPairMultiplier = 0.5
+
+const slotReels = 3
+
+// Slots spins the three reels. Three of the same symbol pays the most
+func Slots(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	if !input.NumberOfArgsAre(1) {
+		utils.SendMessageFailure(m, fmt.Sprintf("Provide a bet, like '%sslots 100'", config.CONFIG.BotPrefix))
+		return
+	}
+
+	var user database.User
+	user.QueryUserByDiscordID(m.Author.ID)
+
+	var response string
+	bet, ok := parseBet(input.GetArgsLowercase()[0], &user, &response)
+	if !ok || !useCooldown("slots", m.Author.ID, &response) {
+		utils.SendMessageFailure(m, response)
+		return
+	}
+
+	reels := spinSlots(rng)
+	difference, ok := settle(&user, 0, bet, payout(bet, slotsMultiplier(reels), config.CONFIG.Gambling.SlotsHouseEdge), "slots")
+	if !ok {
+		utils.SendMessageFailure(m, "You can't afford that bet anymore!")
+		return
+	}
+
+	var symbols []string
+	for _, symbol := range reels {
+		symbols = append(symbols, slotSymbols[symbol].emoji)
+	}
+
+	response = fmt.Sprintf("| %s |\n\n%s\nYour balance: ``%s`` %s", strings.Join(symbols, " | "), resultText(difference), user.PrettyPrintMoney(), config.CONFIG.Economy.Name)
+	if difference > 0 {
+		utils.SendMessageSuccess(m, response)
+	} else {
+		utils.SendMessageFailure(m, response)
+	}
+}
+
+// spinSlots returns the index of the symbol each reel landed on
+func spinSlots(rng RNG) [slotReels]int {
+
+	totalWeight := 0
+	for _, symbol := range slotSymbols {
+		totalWeight += symbol.weight
+	}
+
+	var reels [slotReels]int
+	for i := range reels {
+		roll := rng.Intn(totalWeight)
+		for j, symbol := range slotSymbols {
+			if roll < symbol.weight {
+				reels[i] = j
+				break
+			}
+			roll -= symbol.weight
+		}
+	}
+	return reels
+}
+
+// slotsMultiplier returns the total return of the reels before the house edge
+func slotsMultiplier(reels [slotReels]int) float64 {
+
+	count := map[int]int{}
+	best := 0
+	for _, symbol := range reels {
+		count[symbol]++
+		if count[symbol] > count[best] {
+			best = symbol
+		}
+	}
+
+	switch count[best] {
+	case 3:
+		return slotSymbols[best].multiplier
+	case 2:
+		return slotsPairMultiplier
+	}
+	return 0
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+const moneyHistoryLength = 10
+
+// History shows the newest entries in the money history of the user
+func History(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	var user database.User
+	user.QueryUserByDiscordID(m.Author.ID)
+
+	history := user.QueryMoneyHistory(moneyHistoryLength)
+	if len(history) == 0 {
+		utils.SendMessageNeutral(m, "Your money history is empty")
+		return
+	}
+
+	var lines []string
+	for _, entry := range history {
+
+		amount := fmt.Sprintf("+%s", utils.HumanReadableNumber(int(entry.Amount)))
+		if entry.Amount < 0 {
+			amount = fmt.Sprintf("-%s", utils.HumanReadableNumber(int(-entry.Amount)))
+		}
+
+		lines = append(lines, fmt.Sprintf("<t:%d:R> **%s** ``%s`` %s", entry.CreatedAt.Unix(), entry.Reason, amount, config.CONFIG.Economy.Name))
+	}
+
+	complexMessage := &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{
+		{
+			Type:        discordgo.EmbedTypeRich,
+			Color:       config.CONFIG.Colors.Neutral,
+			Title:       "Money history",
+			Description: strings.Join(lines, "\n"),
+			Footer: &discordgo.MessageEmbedFooter{
+				Text: fmt.Sprintf("The newest %d entries", moneyHistoryLength),
+			},
+		},
+	}}
+
+	if _, err := s.ChannelMessageSendComplex(m.ChannelID, complexMessage); err != nil {
+		malm.Error("Could not send message! %s", err)
+	}
+}
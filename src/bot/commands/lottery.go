@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Lottery shows the pot and the next draw, or buys tickets with 'buy'
+func Lottery(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	var lottery database.Lottery
+	lottery.QueryLottery()
+
+	if input.NumberOfArgsAre(0) {
+		lotteryOverview(s, m, &lottery)
+		return
+	}
+
+	args := input.GetArgsLowercase()
+	if args[0] != "buy" {
+		utils.SendMessageFailure(m, fmt.Sprintf("Unknown argument! Use '%slottery buy [tickets]' to buy tickets", config.CONFIG.BotPrefix))
+		return
+	}
+
+	count := 1
+	if len(args) > 1 {
+		var err error
+		if count, err = strconv.Atoi(args[1]); err != nil || count <= 0 {
+			utils.SendMessageFailure(m, fmt.Sprintf("'%s' is not a valid number of tickets!", args[1]))
+			return
+		}
+	}
+
+	var user database.User
+	user.QueryUserByDiscordID(m.Author.ID)
+
+	var response string
+	if lottery.BuyTickets(&user, count, &response) {
+		utils.SendMessageSuccess(m, response)
+	} else {
+		utils.SendMessageFailure(m, response)
+	}
+}
+
+func lotteryOverview(s *discordgo.Session, m *discordgo.MessageCreate, lottery *database.Lottery) {
+
+	description := fmt.Sprintf("Tickets cost ``%s`` %s each. Buy them with '%slottery buy [tickets]'.\nEvery ticket has the same chance to win the pot",
+		utils.HumanReadableNumber(config.CONFIG.Lottery.TicketPrice),
+		config.CONFIG.Economy.Name,
+		config.CONFIG.BotPrefix)
+
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:   "Pot",
+			Value:  fmt.Sprintf("%s %s", config.CONFIG.Emojis.Economy, utils.HumanReadableNumber(lottery.Pot)),
+			Inline: true,
+		},
+		{
+			Name:   "Next draw",
+			Value:  fmt.Sprintf("<t:%d:R>", lottery.DrawAt.Unix()),
+			Inline: true,
+		},
+		{
+			Name:   "Your tickets",
+			Value:  fmt.Sprintf("%d of %d", lottery.TicketsOf(m.Author.ID), lottery.TicketsTotal),
+			Inline: true,
+		},
+	}
+
+	if len(lottery.LastWinner) > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  "Previous winner",
+			Value: fmt.Sprintf("<@%s> won ``%s`` %s with %d tickets in the draw", lottery.LastWinner, utils.HumanReadableNumber(lottery.LastPrize), config.CONFIG.Economy.Name, lottery.LastTickets),
+		})
+	}
+
+	complexMessage := &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{
+		{
+			Type:        discordgo.EmbedTypeRich,
+			Color:       config.CONFIG.Colors.Neutral,
+			Title:       ":tickets: Lottery",
+			Description: description,
+			Fields:      fields,
+		},
+	}}
+
+	if _, err := s.ChannelMessageSendComplex(m.ChannelID, complexMessage); err != nil {
+		malm.Error("Could not send message! %s", err)
+	}
+}
@@ -1,8 +1,14 @@
 package commands
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
 	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
 	"github.com/CarlFlo/malm"
 	"github.com/bwmarrin/discordgo"
 )
@@ -12,6 +18,11 @@ func Profile(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.Cm
 	var user database.User
 	user.QueryUserByDiscordID(m.Author.ID)
 
+	if input.NumberOfArgsAreAtleast(1) {
+		customizeProfile(m, &user, input)
+		return
+	}
+
 	var work database.Work
 	work.GetWorkInfo(&user)
 
@@ -24,6 +35,16 @@ func Profile(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.Cm
 
 	user.CreateProfileEmbeds(m.Author, &work, &daily, &complexMessage.Embeds)
 
+	// The card is a plain attachment, so refreshing the profile keeps it
+	if isProfileCardEnabled() {
+		stats := user.QueryProfileStats(&work, &daily)
+		if file, err := createProfileCardFile(m.Author, &user, &stats); err != nil {
+			malm.Error("Could not create the profile card! %s", err)
+		} else {
+			complexMessage.Files = []*discordgo.File{file}
+		}
+	}
+
 	/*
 		if components := user.CreateProfileComponents(&work, &daily); components != nil {
 			complexMessage.Components = components
@@ -62,3 +83,68 @@ func ProfileUpdateMessageEdit(user *database.User, work *database.Work, daily *d
 		}
 	*/
 }
+
+// customizeProfile sets the bio or the accent color of the profile
+func customizeProfile(m *discordgo.MessageCreate, user *database.User, input *structs.CmdInput) {
+
+	switch input.GetArgsLowercase()[0] {
+	case "bio":
+		bio := strings.Join(input.GetArgs()[1:], " ")
+		if !user.SetBio(bio) {
+			utils.SendMessageFailure(m, fmt.Sprintf("The bio can be at most %d characters long!", config.CONFIG.Profile.MaxBioLength))
+			return
+		}
+		user.Save()
+
+		if len(user.Bio) == 0 {
+			utils.SendMessageSuccess(m, "Your bio was removed")
+		} else {
+			utils.SendMessageSuccess(m, "Your bio was updated")
+		}
+	case "color", "colour":
+		if !input.NumberOfArgsAre(2) {
+			utils.SendMessageFailure(m, fmt.Sprintf("Provide a hex color like '%sprofile color #ff8800', or 'reset'", config.CONFIG.BotPrefix))
+			return
+		}
+
+		color, ok := parseAccentColor(input.GetArgsLowercase()[1])
+		if !ok {
+			utils.SendMessageFailure(m, "That is not a valid hex color! Use a color like #ff8800")
+			return
+		}
+		user.AccentColor = color
+		user.Save()
+
+		if color == 0 {
+			utils.SendMessageSuccess(m, "Your profile color was reset")
+		} else {
+			utils.SendMessageSuccess(m, fmt.Sprintf("Your profile color was set to #%06x", color))
+		}
+	default:
+		utils.SendMessageFailure(m, fmt.Sprintf("Unknown argument! Use '%sprofile bio <text>' or '%sprofile color <hex>'", config.CONFIG.BotPrefix, config.CONFIG.BotPrefix))
+	}
+}
+
+// parseAccentColor parses a hex color like #ff8800. 'reset' returns 0, which is the default color
+func parseAccentColor(input string) (int, bool) {
+
+	if input == "reset" {
+		return 0, true
+	}
+
+	input = strings.TrimPrefix(input, "#")
+	if len(input) != 6 {
+		return 0, false
+	}
+
+	color, err := strconv.ParseUint(input, 16, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	// Black would be the same as no color, so it is made the closest color instead
+	if color == 0 {
+		color = 1
+	}
+	return int(color), true
+}
@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/bwmarrin/discordgo"
+)
+
+/*
+	The profile card is an image of the profile, drawn without any external services.
+	It is attached to the profile message when turned on in the config
+*/
+
+const (
+	profileCardWidth  = 640
+	profileCardHeight = 240
+	profileCardName   = "profile.png"
+)
+
+var (
+	profileCardBackground = color.RGBA{0x23, 0x27, 0x2a, 0xff}
+	profileCardPanel      = color.RGBA{0x2c, 0x2f, 0x33, 0xff}
+	profileCardText       = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	profileCardSubtle     = color.RGBA{0x99, 0xaa, 0xb5, 0xff}
+)
+
+type profileCard struct {
+	name     string
+	level    int
+	progress float64 // Towards the next level. 0 to 1
+	lines    []string
+	accent   color.RGBA
+}
+
+// createProfileCardFile creates the profile card of the user as a file that can be attached to a message
+func createProfileCardFile(du *discordgo.User, user *database.User, stats *database.ProfileStats) (*discordgo.File, error) {
+
+	progress := 0.0
+	if stats.LevelXPNeeded > 0 {
+		progress = float64(stats.LevelXP) / float64(stats.LevelXPNeeded)
+	}
+
+	card := profileCard{
+		name:     du.Username,
+		level:    stats.Level,
+		progress: progress,
+		lines: []string{
+			fmt.Sprintf("Net worth: %s", utils.HumanReadableNumber(stats.NetWorth)),
+			fmt.Sprintf("Rank: #%d", stats.Rank),
+			fmt.Sprintf("Streaks: work %d, daily %d", stats.WorkStreak, stats.DailyStreak),
+			fmt.Sprintf("Achievements: %d/%d", stats.Achievements, stats.TotalAchievements),
+		},
+		accent: colorFromInt(user.ProfileColor()),
+	}
+
+	data, err := renderProfileCard(&card)
+	if err != nil {
+		return nil, err
+	}
+
+	return &discordgo.File{
+		Name:        profileCardName,
+		ContentType: "image/png",
+		Reader:      bytes.NewReader(data),
+	}, nil
+}
+
+// renderProfileCard draws the card and encodes it as a PNG
+func renderProfileCard(card *profileCard) ([]byte, error) {
+
+	img := image.NewRGBA(image.Rect(0, 0, profileCardWidth, profileCardHeight))
+
+	fillRect(img, 0, 0, profileCardWidth, profileCardHeight, profileCardBackground)
+	fillRect(img, 0, 0, 12, profileCardHeight, card.accent)
+	fillRect(img, 32, 88, profileCardWidth-56, profileCardHeight-108, profileCardPanel)
+
+	// The name and the level. Long names are cut so they don't run into the level
+	levelText := fmt.Sprintf("Level %d", card.level)
+	levelX := profileCardWidth - 24 - textWidth(levelText, 3)
+	drawText(img, levelText, levelX, 24, 3, card.accent)
+
+	name := []rune(card.name)
+	for len(name) > 0 && 32+textWidth(string(name), 4) > levelX-16 {
+		name = name[:len(name)-1]
+	}
+	drawText(img, string(name), 32, 20, 4, profileCardText)
+
+	// Progress towards the next level
+	barWidth := profileCardWidth - 56
+	fillRect(img, 32, 62, barWidth, 12, profileCardPanel)
+	fillRect(img, 32, 62, int(float64(barWidth)*clamp(card.progress, 0, 1)), 12, card.accent)
+
+	for i, line := range card.lines {
+		drawText(img, line, 48, 104+i*28, 2, profileCardSubtle)
+	}
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// colorFromInt converts a color like 0xff8800 to RGBA
+func colorFromInt(c int) color.RGBA {
+	return color.RGBA{uint8(c >> 16), uint8(c >> 8), uint8(c), 0xff}
+}
+
+func clamp(value, min, max float64) float64 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+// isProfileCardEnabled returns true if the profile card should be attached to the profile
+func isProfileCardEnabled() bool {
+	return config.CONFIG.Profile.CardImage
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+func TestRenderProfileCard(t *testing.T) {
+
+	card := profileCard{
+		name:     "Username",
+		level:    12,
+		progress: 0.5,
+		lines:    []string{"Net worth: 1,234", "Rank: #1", "Unknown: ~"},
+		accent:   colorFromInt(0xff8800),
+	}
+
+	data, err := renderProfileCard(&card)
+	if err != nil {
+		t.Fatalf("Could not render the card: %s", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("The card is not a valid PNG: %s", err)
+	}
+
+	test.Validate(t, img.Bounds().Dx(), profileCardWidth, "The card has the wrong width")
+	test.Validate(t, img.Bounds().Dy(), profileCardHeight, "The card has the wrong height")
+
+	// The accent stripe on the left
+	r, g, b, _ := img.At(0, 0).RGBA()
+	test.Validate(t, [3]uint32{r >> 8, g >> 8, b >> 8}, [3]uint32{0xff, 0x88, 0x00}, "The accent color is missing")
+}
+
+func TestParseAccentColor(t *testing.T) {
+
+	color, ok := parseAccentColor("#ff8800")
+	test.Validate(t, ok, true, "A hex color should be valid")
+	test.Validate(t, color, 0xff8800, "The color was parsed wrong")
+
+	color, ok = parseAccentColor("reset")
+	test.Validate(t, ok, true, "Reset should be valid")
+	test.Validate(t, color, 0, "Reset should give the default color")
+
+	for _, input := range []string{"ff88", "#gg8800", "-fffff", "#ff88001"} {
+		_, ok = parseAccentColor(input)
+		test.Validate(t, ok, false, "'"+input+"' should not be valid")
+	}
+}
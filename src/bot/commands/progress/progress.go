@@ -0,0 +1,63 @@
+package progress
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+/*
+	Event is the hook run after the user has done an activity. Every activity should call it once the user has been saved.
+	The user gets XP for the activity, is rewarded for new levels and achievements, and they are announced in the channel
+*/
+
+// Event gives the user XP for doing the activity a number of times. Level ups and new achievements are announced in the channel
+func Event(s *discordgo.Session, channelID string, discordID string, activity database.Activity, times int) {
+
+	var user database.User
+	user.QueryUserByDiscordID(discordID)
+	if user.ID == 0 {
+		return
+	}
+
+	levelRewards := user.AddActivityXP(activity, times)
+	unlocked := user.CheckAchievements()
+
+	user.Save()
+
+	if len(levelRewards) == 0 && len(unlocked) == 0 {
+		return
+	}
+
+	var lines []string
+	for _, reward := range levelRewards {
+		lines = append(lines, fmt.Sprintf("%s Reached **level %d**! (+%s)", config.CONFIG.Emojis.Success, reward.Level, reward))
+	}
+
+	for _, a := range unlocked {
+		lines = append(lines, fmt.Sprintf("%s Unlocked **%s** - %s (+%s %s)", a.Badge, a.Name, a.Description, utils.HumanReadableNumber(a.Reward), config.CONFIG.Economy.Name))
+	}
+
+	title := "Level Up!"
+	if len(levelRewards) == 0 {
+		title = "Achievement Unlocked!"
+	}
+
+	complexMessage := &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{
+		{
+			Type:        discordgo.EmbedTypeRich,
+			Color:       config.CONFIG.Colors.Success,
+			Title:       title,
+			Description: fmt.Sprintf("<@%s>\n%s\n\n%s", discordID, strings.Join(lines, "\n"), user.LevelDescription()),
+		},
+	}}
+
+	if _, err := s.ChannelMessageSendComplex(channelID, complexMessage); err != nil {
+		malm.Error("Could not send message! %s", err)
+	}
+}
@@ -1,15 +1,18 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
 	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
 	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
 	"github.com/CarlFlo/malm"
 
 	"github.com/bwmarrin/discordgo"
 )
 
-// Reload - Reloads the configuration without restarting the application
+// Reload - Reloads the configuration and the crops without restarting the application
 func Reload(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
 
 	if err := config.ReloadConfig(); err != nil {
@@ -17,5 +20,11 @@ func Reload(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.Cmd
 		return
 	}
 
-	utils.SendDirectMessage(m, "Config reloaded")
+	if err := database.SyncCrops(); err != nil {
+		malm.Error("Could not reload crops! %s", err)
+		utils.SendDirectMessage(m, fmt.Sprintf("Config reloaded, but the crops could not be reloaded: %s", err))
+		return
+	}
+
+	utils.SendDirectMessage(m, "Config and crops reloaded")
 }
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/bwmarrin/discordgo"
+)
+
+// StreakFreeze shows how many streak freezes the user owns, or buys one with 'buy'
+func StreakFreeze(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	var user database.User
+	user.QueryUserByDiscordID(m.Author.ID)
+
+	if input.NumberOfArgsAre(0) {
+		utils.SendMessageNeutral(m, fmt.Sprintf("%s You own %d/%d streak freezes. A freeze keeps your work or daily streak when you miss it, one freeze for each missed period.\nBuy one for %s %s with '%sfreeze buy'",
+			config.CONFIG.Emojis.StreakFreeze,
+			user.StreakFreezes,
+			config.CONFIG.StreakFreeze.MaxOwned,
+			utils.HumanReadableNumber(config.CONFIG.StreakFreeze.Price),
+			config.CONFIG.Economy.Name,
+			config.CONFIG.BotPrefix))
+		return
+	}
+
+	if input.GetArgsLowercase()[0] != "buy" {
+		utils.SendMessageFailure(m, fmt.Sprintf("Unknown argument! Use '%sfreeze buy' to buy a streak freeze", config.CONFIG.BotPrefix))
+		return
+	}
+
+	var response string
+	if buyStreakFreezeShared(&user, &response) {
+		utils.SendMessageSuccess(m, response)
+		user.Save()
+	} else {
+		utils.SendMessageFailure(m, response)
+	}
+}
+
+// BuyStreakFreezeInteraction buys a streak freeze from the profile message
+func BuyStreakFreezeInteraction(authorID string, response *string, author *discordgo.User, me *discordgo.MessageEdit) {
+
+	var user database.User
+	user.QueryUserByDiscordID(authorID)
+
+	// The response is only shown on failure, the profile shows the new amount
+	var failure string
+	if !buyStreakFreezeShared(&user, &failure) {
+		*response = failure
+		return
+	}
+	user.Save()
+
+	var work database.Work
+	work.GetWorkInfo(&user)
+
+	var daily database.Daily
+	daily.GetDailyInfo(&user)
+
+	ProfileUpdateMessageEdit(&user, &work, &daily, author, me)
+}
+
+// buyStreakFreezeShared buys a streak freeze for the user
+// Returns true if success, else false
+func buyStreakFreezeShared(user *database.User, response *string) bool {
+
+	if user.HasMaxStreakFreezes() {
+		*response = fmt.Sprintf("You already own the maximum of %d streak freezes!", config.CONFIG.StreakFreeze.MaxOwned)
+		return false
+	}
+
+	price := uint64(config.CONFIG.StreakFreeze.Price)
+	if !user.CanAfford(price) {
+		*response = fmt.Sprintf("You are lacking ``%s`` %s for this transaction.\nYour balance: ``%s`` %s", utils.HumanReadableNumber(price-user.Money), config.CONFIG.Economy.Name, user.PrettyPrintMoney(), config.CONFIG.Economy.Name)
+		return false
+	}
+
+	user.BuyStreakFreeze()
+
+	*response = fmt.Sprintf("%s You bought a streak freeze! You now own %d/%d", config.CONFIG.Emojis.StreakFreeze, user.StreakFreezes, config.CONFIG.StreakFreeze.MaxOwned)
+	return true
+}
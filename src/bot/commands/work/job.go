@@ -0,0 +1,121 @@
+package work
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Job shows the jobs, or switches to the job given as an argument
+func Job(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	var user database.User
+	user.QueryUserByDiscordID(m.Author.ID)
+
+	var work database.Work
+	work.GetWorkInfo(&user)
+
+	if input.NumberOfArgsAre(0) {
+		listJobs(s, m, &work)
+		return
+	}
+
+	// Job names can contain spaces. 'confirm' is not part of the name
+	args := input.GetArgsLowercase()
+	confirmed := args[len(args)-1] == "confirm"
+	if confirmed {
+		args = args[:len(args)-1]
+	}
+
+	var response string
+	if switchJobShared(&work, strings.Join(args, " "), confirmed, &response) {
+		utils.SendMessageSuccess(m, response)
+		work.Save()
+	} else {
+		utils.SendMessageFailure(m, response)
+	}
+}
+
+// switchJobShared switches the job of the user. Switching away from a job with experience has to be confirmed
+// Returns true if success, else false
+func switchJobShared(work *database.Work, jobName string, confirmed bool, response *string) bool {
+
+	job, ok := database.GetJobByName(jobName)
+	if !ok {
+		*response = fmt.Sprintf("The job '%s' does not exist! Use '%sjob' to see the jobs", jobName, config.CONFIG.BotPrefix)
+		return false
+	}
+
+	if work.GetJob().Name == job.Name {
+		*response = fmt.Sprintf("You already work as a %s!", job.Name)
+		return false
+	}
+
+	if !work.CanTakeJob(job) {
+		*response = fmt.Sprintf("You need to have worked %d shifts to become a %s. You have worked %d", job.RequiredShifts, job.Name, work.Shifts)
+		return false
+	}
+
+	if work.JobExperience > 0 && !confirmed {
+		*response = fmt.Sprintf("You will lose your %d experience as a %s if you switch job!\nType '%sjob %s confirm' to switch anyway", work.JobExperience, work.GetJob().Name, config.CONFIG.BotPrefix, strings.ToLower(job.Name))
+		return false
+	}
+
+	work.SwitchJob(job)
+
+	*response = fmt.Sprintf("%s You are now working as a **%s**!", job.Emoji, work.RankTitle())
+	return true
+}
+
+func listJobs(s *discordgo.Session, m *discordgo.MessageCreate, work *database.Work) {
+
+	var fields []*discordgo.MessageEmbedField
+
+	for _, job := range database.GetJobs() {
+
+		status := config.CONFIG.Emojis.Success
+		if job.Name == work.GetJob().Name {
+			status = "Current job"
+		} else if !work.CanTakeJob(job) {
+			status = fmt.Sprintf("%s Requires %d shifts", config.CONFIG.Emojis.Failure, job.RequiredShifts)
+		}
+
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name: fmt.Sprintf("%s %s", job.Emoji, job.Name),
+			Value: fmt.Sprintf("Pays %s - %s %s\nEvery %d hours\nRanks: %s\n%s",
+				utils.HumanReadableNumber(job.MinMoney),
+				utils.HumanReadableNumber(job.MaxMoney),
+				config.CONFIG.Economy.Name,
+				int(job.Cooldown.Hours()),
+				strings.Join(job.Ranks, " > "),
+				status),
+			Inline: true,
+		})
+	}
+
+	complexMessage := &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{
+		{
+			Type:        discordgo.EmbedTypeRich,
+			Color:       config.CONFIG.Colors.Neutral,
+			Title:       "Jobs",
+			Description: fmt.Sprintf("%s\nYou have worked %d shifts in total", work.JobDescription(), work.Shifts),
+			Fields:      fields,
+			Footer: &discordgo.MessageEmbedFooter{
+				Text: fmt.Sprintf("Switch job with '%sjob <name>'. Each promotion raises your pay by %.0f%%", config.CONFIG.BotPrefix, config.CONFIG.Work.PromotionPayBonus*100),
+			},
+			Thumbnail: &discordgo.MessageEmbedThumbnail{
+				URL: fmt.Sprintf("%s#%s", m.Author.AvatarURL("256"), m.Author.ID),
+			},
+		},
+	}}
+
+	if _, err := s.ChannelMessageSendComplex(m.ChannelID, complexMessage); err != nil {
+		malm.Error("Could not send message! %s", err)
+	}
+}
@@ -0,0 +1,164 @@
+package work
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// miniGame is a short task the user has to solve while working. The answer is the index of the right option
+type miniGame struct {
+	prompt  string
+	options []string
+	answer  int
+}
+
+// The different kinds of tasks. One is picked at random each shift
+var miniGames = []func() miniGame{
+	pickItemGame,
+	unscrambleGame,
+	quickMathGame,
+}
+
+// How many options each task has
+const miniGameOptions = 4
+
+type miniGameItem struct {
+	name  string
+	emoji string
+}
+
+var miniGameItems = []miniGameItem{
+	{"apple", "🍎"},
+	{"banana", "🍌"},
+	{"pizza", "🍕"},
+	{"coffee", "☕"},
+	{"book", "📖"},
+	{"hammer", "🔨"},
+	{"package", "📦"},
+	{"key", "🔑"},
+	{"carrot", "🥕"},
+	{"umbrella", "☂️"},
+}
+
+var miniGameWords = []string{
+	"money",
+	"harvest",
+	"shovel",
+	"tomato",
+	"diamond",
+	"paycheck",
+	"promotion",
+	"market",
+	"customer",
+	"delivery",
+}
+
+func newMiniGame() miniGame {
+	return miniGames[rand.Intn(len(miniGames))]()
+}
+
+// pickItemGame asks the user to pick the item the customer wants
+func pickItemGame() miniGame {
+
+	var options []string
+	var wanted string
+	for i, index := range rand.Perm(len(miniGameItems))[:miniGameOptions] {
+		if i == 0 {
+			wanted = miniGameItems[index].name
+		}
+		options = append(options, miniGameItems[index].emoji)
+	}
+
+	return shuffleMiniGame(miniGame{
+		prompt:  fmt.Sprintf("A customer asks for a **%s**. Pick the right item!", wanted),
+		options: options,
+		answer:  0,
+	})
+}
+
+// unscrambleGame asks the user to find the word that was scrambled
+func unscrambleGame() miniGame {
+
+	var options []string
+	for _, index := range rand.Perm(len(miniGameWords))[:miniGameOptions] {
+		options = append(options, miniGameWords[index])
+	}
+
+	return shuffleMiniGame(miniGame{
+		prompt:  fmt.Sprintf("Your boss left a scrambled note: **%s**. What does it say?", scramble(options[0])),
+		options: options,
+		answer:  0,
+	})
+}
+
+// quickMathGame asks the user to solve a simple calculation
+func quickMathGame() miniGame {
+
+	a, b := rand.Intn(19)+2, rand.Intn(19)+2
+
+	var question string
+	var answer int
+	switch rand.Intn(3) {
+	case 0:
+		question, answer = fmt.Sprintf("%d + %d", a, b), a+b
+	case 1:
+		question, answer = fmt.Sprintf("%d - %d", a, b), a-b
+	default:
+		question, answer = fmt.Sprintf("%d × %d", a, b), a*b
+	}
+
+	// The wrong options are close to the answer
+	options := []string{strconv.Itoa(answer)}
+	used := map[int]bool{answer: true}
+	for len(options) < miniGameOptions {
+		wrong := answer + rand.Intn(21) - 10
+		if used[wrong] {
+			continue
+		}
+		used[wrong] = true
+		options = append(options, strconv.Itoa(wrong))
+	}
+
+	return shuffleMiniGame(miniGame{
+		prompt:  fmt.Sprintf("Quick! A customer needs the total of **%s**", question),
+		options: options,
+		answer:  0,
+	})
+}
+
+// shuffleMiniGame shuffles the options and keeps track of where the answer ends up
+func shuffleMiniGame(game miniGame) miniGame {
+
+	answer := game.options[game.answer]
+	rand.Shuffle(len(game.options), func(i, j int) {
+		game.options[i], game.options[j] = game.options[j], game.options[i]
+	})
+
+	for i, option := range game.options {
+		if option == answer {
+			game.answer = i
+		}
+	}
+	return game
+}
+
+// scramble shuffles the letters in the word. The result is never the word itself, unless every letter is the same
+func scramble(word string) string {
+
+	letters := strings.Split(word, "")
+	if strings.Count(word, letters[0]) == len(letters) {
+		return word
+	}
+
+	for {
+		rand.Shuffle(len(letters), func(i, j int) {
+			letters[i], letters[j] = letters[j], letters[i]
+		})
+
+		if scrambled := strings.Join(letters, ""); scrambled != word {
+			return scrambled
+		}
+	}
+}
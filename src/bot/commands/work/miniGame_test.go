@@ -0,0 +1,59 @@
+package work
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+func TestMiniGames(t *testing.T) {
+
+	for kind, create := range miniGames {
+		for i := 0; i < 100; i++ {
+			game := create()
+
+			test.Validate(t, len(game.options), miniGameOptions, "Every task should have the same number of options")
+
+			seen := map[string]bool{}
+			for _, option := range game.options {
+				if seen[option] {
+					t.Errorf("Task %d has the option '%s' more than once", kind, option)
+				}
+				seen[option] = true
+			}
+
+			if game.answer < 0 || game.answer >= len(game.options) {
+				t.Errorf("Task %d has the answer %d outside of the options", kind, game.answer)
+			}
+		}
+	}
+}
+
+func TestQuickMathAnswer(t *testing.T) {
+
+	for i := 0; i < 100; i++ {
+		game := quickMathGame()
+
+		// e.g. 'Quick! A customer needs the total of **12 × 4**'
+		question := strings.Trim(game.prompt[strings.Index(game.prompt, "**"):], "*")
+		parts := strings.Split(question, " ")
+		a, _ := strconv.Atoi(parts[0])
+		b, _ := strconv.Atoi(parts[2])
+
+		expected := map[string]int{"+": a + b, "-": a - b, "×": a * b}[parts[1]]
+		test.Validate(t, game.options[game.answer], strconv.Itoa(expected), "The answer should be the result of the calculation")
+	}
+}
+
+func TestScramble(t *testing.T) {
+
+	for _, word := range miniGameWords {
+		scrambled := scramble(word)
+		test.Validate(t, scrambled != word, true, "The scrambled word should not be the same as the word")
+		test.Validate(t, len(scrambled), len(word), "The scrambled word should have the same letters")
+	}
+
+	test.Validate(t, scramble("aaa"), "aaa", "A word with only one letter can't be scrambled")
+}
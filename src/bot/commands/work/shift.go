@@ -0,0 +1,234 @@
+package work
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/progress"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+/*
+	When mini games are turned on, the user has to solve a short task before they get paid.
+	The work cooldown starts when the task is given, so it can't be skipped by running the command again.
+	How well the task went scales the paycheck. Not answering in time still pays a little
+*/
+
+// shift is a task waiting for the user to answer it
+type shift struct {
+	game         miniGame
+	work         database.Work // The work data when the shift started. The streak bonus is already in the income
+	income       int           // The income before it is scaled by the result
+	thumbnail    *discordgo.MessageEmbedThumbnail
+	channelID    string
+	messageID    string
+	timeoutTimer *time.Timer
+	record       database.WorkShift // Pays the user if the bot is stopped before the shift ends
+}
+
+type shiftResult uint8
+
+const (
+	shiftCorrect shiftResult = iota
+	shiftWrong
+	shiftTimeout
+)
+
+var errShiftPending = errors.New("the user already has a task waiting for an answer")
+
+// The shifts waiting for an answer. The key is the user's discord ID
+var shifts = struct {
+	sync.Mutex
+	pending map[string]*shift
+}{pending: make(map[string]*shift)}
+
+// startShift gives the user a task and sends it to the channel. The work has to be updated with StreakPreMsgAction first
+// The tools are worn down, so the work has to be saved after. Returns errShiftPending if the user already has a task
+func startShift(s *discordgo.Session, channelID string, author *discordgo.User, work *database.Work) error {
+
+	sh := &shift{
+		game:      newMiniGame(),
+		income:    generateWorkIncome(work),
+		channelID: channelID,
+		thumbnail: &discordgo.MessageEmbedThumbnail{
+			URL: fmt.Sprintf("%s#%s", author.AvatarURL("256"), author.ID),
+		},
+	}
+
+	complexMessage := &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       fmt.Sprintf("%s Working as a %s", work.GetJob().Emoji, work.RankTitle()),
+				Description: fmt.Sprintf("%s\n\nAnswer <t:%d:R> to get paid in full!", sh.game.prompt, time.Now().Add(miniGameTimeout()).Unix()),
+				Color:       config.CONFIG.Colors.Neutral,
+				Thumbnail:   sh.thumbnail,
+			},
+		},
+		Components: createMiniGameComponents(&sh.game),
+	}
+
+	// Registered before the message is sent, so a user can't get two tasks at the same time
+	shifts.Lock()
+	if _, ok := shifts.pending[author.ID]; ok {
+		shifts.Unlock()
+		return errShiftPending
+	}
+	shifts.pending[author.ID] = sh
+	shifts.Unlock()
+
+	msg, err := s.ChannelMessageSendComplex(channelID, complexMessage)
+	if err != nil {
+		shifts.Lock()
+		delete(shifts.pending, author.ID)
+		shifts.Unlock()
+		return err
+	}
+
+	// The tools were used for the income
+	work.WearTools()
+	sh.work = *work
+
+	sh.record = database.WorkShift{DiscordID: author.ID, Income: sh.income}
+	sh.record.Start()
+
+	// The task can be answered once the message ID is set, so the timer is started at the same time
+	shifts.Lock()
+	defer shifts.Unlock()
+
+	sh.messageID = msg.ID
+
+	// Pays the user a little if they don't answer in time
+	sh.timeoutTimer = time.AfterFunc(miniGameTimeout(), func() {
+
+		me := &discordgo.MessageEdit{Channel: sh.channelID, ID: sh.messageID}
+		if !finishShift(s, author.ID, sh, shiftTimeout, me) {
+			return
+		}
+
+		if _, err := s.ChannelMessageEditComplex(me); err != nil {
+			malm.Error("Could not edit the work message! %s", err)
+		}
+	})
+
+	return nil
+}
+
+// MiniGameInteraction is called when the user answers the task from the work message
+func MiniGameInteraction(s *discordgo.Session, authorID string, customID string, response *string, me *discordgo.MessageEdit) {
+
+	// The custom ID is 'WMG' followed by the index of the option
+	answer, err := strconv.Atoi(strings.TrimPrefix(customID, "WMG"))
+	if err != nil {
+		malm.Error("Invalid mini game answer: '%s'", customID)
+		return
+	}
+
+	shifts.Lock()
+	sh, ok := shifts.pending[authorID]
+	ok = ok && sh.messageID == me.ID
+	shifts.Unlock()
+
+	if !ok {
+		*response = "This task has already ended!"
+		return
+	}
+
+	result := shiftWrong
+	if answer == sh.game.answer {
+		result = shiftCorrect
+	}
+
+	finishShift(s, authorID, sh, result, me)
+}
+
+// finishShift pays the user for the shift and updates the message to the paycheck
+// Returns false if the shift already has ended
+func finishShift(s *discordgo.Session, authorID string, sh *shift, result shiftResult, me *discordgo.MessageEdit) bool {
+
+	shifts.Lock()
+	pending, ok := shifts.pending[authorID]
+	if !ok || pending != sh {
+		shifts.Unlock()
+		return false
+	}
+	delete(shifts.pending, authorID)
+	shifts.Unlock()
+
+	sh.timeoutTimer.Stop()
+	sh.record.End()
+
+	var user database.User
+	user.QueryUserByDiscordID(authorID)
+
+	multiplier, resultText := miniGameResult(&sh.game, result)
+	moneyEarned := int(float64(sh.income) * multiplier)
+	user.AddMoney(uint64(moneyEarned))
+	user.Save()
+
+	progress.Event(s, sh.channelID, authorID, database.ActivityWork, 1)
+
+	// The tools might have been bought or repaired while the task was waiting
+	var work database.Work
+	work.GetWorkInfo(&user)
+	sh.work.Tools = work.Tools
+
+	me.Embeds = []*discordgo.MessageEmbed{
+		{
+			Title:       createWorkMessageTitle(&sh.work, true),
+			Description: fmt.Sprintf("%s\n\n%s", resultText, createPaycheckDescription(&user, &sh.work, moneyEarned)),
+			Color:       createWorkMessageColor(&sh.work, true),
+			Fields:      createWorkMessageFields(&sh.work, true),
+			Footer:      createWorkMessageFooter(&sh.work, true),
+			Thumbnail:   sh.thumbnail,
+		},
+	}
+
+	// Removes the answer buttons
+	me.Components = work.CreateMessageComponents()
+	if me.Components == nil {
+		me.Components = []discordgo.MessageComponent{}
+	}
+	return true
+}
+
+// miniGameResult returns how much the paycheck is scaled and a text describing the result
+func miniGameResult(game *miniGame, result shiftResult) (float64, string) {
+
+	switch result {
+	case shiftCorrect:
+		multiplier := config.CONFIG.Work.MiniGameCorrectMultiplier
+		return multiplier, fmt.Sprintf("%s Great work! You were paid %.2fx", config.CONFIG.Emojis.Success, multiplier)
+	case shiftWrong:
+		multiplier := config.CONFIG.Work.MiniGameWrongMultiplier
+		return multiplier, fmt.Sprintf("%s That was wrong, the answer was %s. You were paid %.2fx", config.CONFIG.Emojis.Failure, game.options[game.answer], multiplier)
+	}
+
+	multiplier := config.CONFIG.Work.MiniGameTimeoutMultiplier
+	return multiplier, fmt.Sprintf("%s You were too slow, the answer was %s. You were paid %.2fx", config.CONFIG.Emojis.Failure, game.options[game.answer], multiplier)
+}
+
+func miniGameTimeout() time.Duration {
+	return time.Second * time.Duration(config.CONFIG.Work.MiniGameSeconds)
+}
+
+// The custom ID of each button is 'WMG' followed by the index of the option
+func createMiniGameComponents(game *miniGame) []discordgo.MessageComponent {
+
+	var buttons []discordgo.MessageComponent
+	for i, option := range game.options {
+		buttons = append(buttons, &discordgo.Button{
+			Label:    option,
+			Style:    1,                       // Default purple
+			CustomID: fmt.Sprintf("WMG%d", i), // 'WMG' is code for 'Work Mini Game'
+		})
+	}
+
+	return []discordgo.MessageComponent{discordgo.ActionsRow{Components: buttons}}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/rand"
 
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/progress"
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
 	"github.com/CarlFlo/DiscordMoneyBot/src/config"
 	"github.com/CarlFlo/DiscordMoneyBot/src/database"
@@ -28,7 +29,23 @@ func Work(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdIn
 	canWork := work.CanDoWork()
 
 	// Reset streak if user hasn't worked in a specified amount of time (set in config)
-	work.StreakPreMsgAction()
+	work.StreakPreMsgAction(&user)
+
+	// The user has to solve a task before getting paid
+	if canWork && config.CONFIG.Work.MiniGames {
+		if err := startShift(s, m.ChannelID, m.Author, &work); err == errShiftPending {
+			utils.SendMessageFailure(m, "Finish your current task first!")
+			return
+		} else if err != nil {
+			malm.Error("Could not send message! (Data not saved) %s", err)
+			return
+		}
+
+		work.StreakPostMsgAction()
+		saveUsedStreakFreezes(&user, &work)
+		work.Save()
+		return
+	}
 
 	// TODO: Change to how farm was reworked
 
@@ -63,6 +80,10 @@ func Work(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdIn
 
 	user.Save()
 	work.Save()
+
+	if canWork {
+		progress.Event(s, m.ChannelID, m.Author.ID, database.ActivityWork, 1)
+	}
 }
 
 // Returns the work title string
@@ -78,30 +99,45 @@ func createWorkMessageTitle(work *database.Work, canDoWork bool) string {
 // Will also give the user money if they can work
 func createWorkMessageDescription(user *database.User, work *database.Work, canDoWork bool) string {
 
-	toolsTooltip := generateToolTooltip(work)
-
 	var description string
 
 	if canDoWork {
 
-		// Calculates the income
+		// Calculates the income. The tools wear down after being used
 		moneyEarned := generateWorkIncome(work)
+		work.WearTools()
 		user.AddMoney(uint64(moneyEarned))
 
-		moneyEarnedString := utils.HumanReadableNumber(moneyEarned)
-
-		description = fmt.Sprintf("%sYou earned ``%s`` %s! Your new balance is ``%s`` %s!\nYou will be able to work again %s\nCurrent streak: ``%d``\n\n%s",
-			config.CONFIG.Emojis.Economy,
-			moneyEarnedString,
-			config.CONFIG.Economy.Name,
-			user.PrettyPrintMoney(),
-			config.CONFIG.Economy.Name,
-			work.CanDoWorkAt(),
-			work.ConsecutiveStreaks,
-			toolsTooltip)
+		description = createPaycheckDescription(user, work, moneyEarned)
 
 	} else {
-		description = fmt.Sprintf("You can work again %s\n\n%s", work.CanDoWorkAt(), toolsTooltip)
+		description = fmt.Sprintf("You can work again %s\n\n%s", work.CanDoWorkAt(), work.JobDescription())
+	}
+
+	return description
+}
+
+// createPaycheckDescription describes what the user earned. The money has to be added to the user first
+func createPaycheckDescription(user *database.User, work *database.Work, moneyEarned int) string {
+
+	moneyEarnedString := utils.HumanReadableNumber(moneyEarned)
+
+	description := fmt.Sprintf("%sYou earned ``%s`` %s! Your new balance is ``%s`` %s!\nYou will be able to work again %s\nCurrent streak: ``%d``\n\n%s",
+		config.CONFIG.Emojis.Economy,
+		moneyEarnedString,
+		config.CONFIG.Economy.Name,
+		user.PrettyPrintMoney(),
+		config.CONFIG.Economy.Name,
+		work.CanDoWorkAt(),
+		work.ConsecutiveStreaks,
+		work.JobDescription())
+
+	if notice := database.StreakFreezeNotice(work.UsedStreakFreezes); len(notice) > 0 {
+		description = fmt.Sprintf("%s\n%s", notice, description)
+	}
+
+	if work.Promoted {
+		description = fmt.Sprintf("**You were promoted to %s!** Your pay is now %.2fx\n%s", work.RankTitle(), work.PayMultiplier(), description)
 	}
 
 	return description
@@ -123,21 +159,31 @@ func createWorkMessageFields(work *database.Work, canDoWork bool) []*discordgo.M
 			Name:  fmt.Sprintf("Extra Reward Progress (%s)", percentage),
 			Value: extraRewardValue,
 		},
+		createToolsField(work),
+	}
+}
+
+// The tools field is replaced when a tool is bought, upgraded or repaired
+func createToolsField(work *database.Work) *discordgo.MessageEmbedField {
+	return &discordgo.MessageEmbedField{
+		Name:  toolsFieldName(),
+		Value: work.ToolsDescription(),
 	}
 }
 
+func toolsFieldName() string {
+	return fmt.Sprintf("Tools %s", config.CONFIG.Emojis.Tools)
+}
+
 func createWorkMessageFooter(work *database.Work, canDoWork bool) *discordgo.MessageEmbedFooter {
 
-	footerText := fmt.Sprintf("You can work once every %d hours!", int(config.CONFIG.Work.Cooldown))
+	footerText := fmt.Sprintf("You can work once every %d hours as a %s! Use '%sjob' to change career", int(work.GetJob().Cooldown.Hours()), work.GetJob().Name, config.CONFIG.BotPrefix)
 
 	if canDoWork {
-		footerText = fmt.Sprintf("The streak resets after %d hours of inactivity and will reward %d %s on completion!\nEach tool you buy will earn you an additional %s %s when you work! (Max %d)",
+		footerText = fmt.Sprintf("The streak resets after %d hours of inactivity and will reward %d %s on completion!\nTools raise your pay, but wear down each shift and have to be repaired when they break!",
 			config.CONFIG.Work.StreakResetHours,
 			config.CONFIG.Work.StreakBonus,
-			config.CONFIG.Economy.Name,
-			utils.HumanReadableNumber(config.CONFIG.Work.ToolBonus),
-			config.CONFIG.Economy.Name,
-			config.CONFIG.Work.MaxTools)
+			config.CONFIG.Economy.Name)
 	}
 
 	return &discordgo.MessageEmbedFooter{
@@ -145,28 +191,6 @@ func createWorkMessageFooter(work *database.Work, canDoWork bool) *discordgo.Mes
 	}
 }
 
-func generateToolTooltip(work *database.Work) string {
-
-	numOfBoughtTools := int(work.Tools)
-
-	if numOfBoughtTools == 0 {
-		return fmt.Sprintf("%s You have 0 tools", config.CONFIG.Emojis.Tools)
-	}
-
-	wordFormat := "tools"
-	if numOfBoughtTools == 1 {
-		wordFormat = "tool"
-	}
-
-	bonus := utils.HumanReadableNumber(numOfBoughtTools * config.CONFIG.Work.ToolBonus)
-	return fmt.Sprintf("%s You have %d %s, giving you an additional **%s** %s",
-		config.CONFIG.Emojis.Tools,
-		numOfBoughtTools,
-		wordFormat,
-		bonus,
-		config.CONFIG.Economy.Name)
-}
-
 func generateWorkStreakMessage(streak uint16, addStreakMessage bool) (string, string) {
 
 	percentage := float64(streak) / float64(len(config.CONFIG.Work.StreakOutput))
@@ -194,15 +218,35 @@ func generateWorkStreakMessage(streak uint16, addStreakMessage bool) (string, st
 
 func generateWorkIncome(work *database.Work) int {
 
-	// Generate a random int between config.CONFIG.Work.MinMoney and config.CONFIG.Work.MaxMoney
-	moneyEarned := rand.Intn(config.CONFIG.Work.MaxMoney-config.CONFIG.Work.MinMoney) + config.CONFIG.Work.MinMoney
+	job := work.GetJob()
+
+	// Generate a random int between the min and max pay of the job. Promotions and tools raise the pay
+	moneyEarned := rand.Intn(job.MaxMoney-job.MinMoney+1) + job.MinMoney
+	moneyEarned = int(float64(moneyEarned) * (work.PayMultiplier() + work.ToolPayMultiplier() - 1))
 
 	// Adds the streak bonus to the amount
 	if work.Streak == uint16(len(config.CONFIG.Work.StreakOutput)) {
 		moneyEarned += config.CONFIG.Work.StreakBonus
 	}
 
-	moneyEarned += int(work.Tools) * config.CONFIG.Work.ToolBonus
+	moneyEarned += work.ToolBonus()
 
 	return moneyEarned
 }
+
+// saveUsedStreakFreezes saves the streak freezes used by StreakPreMsgAction. The user is queried again first,
+// since their money could have changed while the task was sent
+func saveUsedStreakFreezes(user *database.User, work *database.Work) {
+
+	if work.UsedStreakFreezes == 0 {
+		return
+	}
+
+	user.QueryUserByDiscordID(user.DiscordID)
+	if int(user.StreakFreezes) < work.UsedStreakFreezes {
+		user.StreakFreezes = 0
+	} else {
+		user.StreakFreezes -= uint8(work.UsedStreakFreezes)
+	}
+	user.Save()
+}
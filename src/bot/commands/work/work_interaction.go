@@ -2,68 +2,92 @@ package work
 
 import (
 	"fmt"
-	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands"
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/progress"
 	"github.com/CarlFlo/DiscordMoneyBot/src/config"
 	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/malm"
 	"github.com/bwmarrin/discordgo"
 )
 
-func BuyToolInteraction(authorID string, response *string, i *discordgo.Interaction, me *discordgo.MessageEdit) {
+// ToolInteraction buys, upgrades or repairs a tool from the work message
+// The custom ID is 'WTU' (upgrade) or 'WTR' (repair) followed by the index of the tool
+func ToolInteraction(authorID string, customID string, response *string, i *discordgo.Interaction, me *discordgo.MessageEdit) {
+
+	index, err := strconv.Atoi(customID[3:])
+	tools := database.GetTools()
+	if err != nil || index < 0 || index >= len(tools) {
+		*response = "This tool does not exist anymore!"
+		return
+	}
+	tool := tools[index]
 
-	// Check if the user has enough money
 	var user database.User
 	user.QueryUserByDiscordID(authorID)
 
 	var work database.Work
 	work.GetWorkInfo(&user)
 
-	price, _ := work.CalcBuyToolPrice()
+	var price int
+	if strings.HasPrefix(customID, "WTR") {
 
-	if uint64(price) > user.Money {
-		difference := uint64(price) - user.Money
-		*response = fmt.Sprintf("You are lacking ``%d`` %s for this transaction.\nYour balance: ``%d`` %s", difference, config.CONFIG.Economy.Name, user.Money, config.CONFIG.Economy.Name)
-		return
+		if price = work.ToolRepairPrice(tool); price == 0 {
+			*response = fmt.Sprintf("Your %s does not need to be repaired!", tool.Name)
+			return
+		}
+	} else {
+
+		var ok bool
+		if price, ok = work.ToolUpgradePrice(tool); !ok {
+			*response = fmt.Sprintf("Your %s is already fully upgraded!", tool.Name)
+			return
+		}
 	}
 
-	if work.HasHitMaxToolLimit() {
-		*response = fmt.Sprintf("You have reached the maximum number of tools you can buy! Max %d", config.CONFIG.Work.MaxTools)
+	if !user.CanAfford(uint64(price)) {
+		difference := uint64(price) - user.Money
+		*response = fmt.Sprintf("You are lacking ``%d`` %s for this transaction.\nYour balance: ``%d`` %s", difference, config.CONFIG.Economy.Name, user.Money, config.CONFIG.Economy.Name)
 		return
 	}
 
 	user.DeductMoney(uint64(price))
 
-	work.Tools += 1
-
-	// Update the message as well to reflect that a new tool was bought.
-	patternString := fmt.Sprintf(`%s .+ \d+ tool.+`, config.CONFIG.Emojis.Tools)
+	if strings.HasPrefix(customID, "WTR") {
+		work.RepairTool(tool)
+	} else {
+		work.UpgradeTool(tool)
+	}
 
-	pattern := regexp.MustCompile(patternString)
-	modifiedMsg := pattern.ReplaceAllString(i.Message.Embeds[0].Description, generateToolTooltip(&work))
+	// Update the message as well to reflect the new state of the tools
+	var fields []*discordgo.MessageEmbedField
+	for _, field := range i.Message.Embeds[0].Fields {
+		if field.Name == toolsFieldName() {
+			field = createToolsField(&work)
+		}
+		fields = append(fields, field)
+	}
 
 	me.Embeds = append(me.Embeds, &discordgo.MessageEmbed{
 		Title:       i.Message.Embeds[0].Title,
-		Description: modifiedMsg,
+		Description: i.Message.Embeds[0].Description,
 		Color:       i.Message.Embeds[0].Color,
-		Fields:      i.Message.Embeds[0].Fields,
+		Fields:      fields,
 		Footer:      i.Message.Embeds[0].Footer,
 		Thumbnail:   i.Message.Embeds[0].Thumbnail,
 	})
 
 	me.Components = work.CreateMessageComponents()
-	/*
-		if components := work.CreateMessageComponents(); components != nil {
-			me.Components = components
-		}
-	*/
 
 	user.Save()
 	work.Save()
 }
 
 // From the profile message
-func DoWorkInteraction(authorID string, response *string, author *discordgo.User, me *discordgo.MessageEdit) {
+// The task is sent to the channel when mini games are turned on
+func DoWorkInteraction(authorID string, response *string, author *discordgo.User, s *discordgo.Session, channelID string, me *discordgo.MessageEdit) {
 
 	var user database.User
 	user.QueryUserByDiscordID(authorID)
@@ -73,15 +97,37 @@ func DoWorkInteraction(authorID string, response *string, author *discordgo.User
 
 	canDoWork := work.CanDoWork()
 
-	work.StreakPreMsgAction()
+	work.StreakPreMsgAction(&user)
 
-	*response = createWorkMessageDescription(&user, &work, canDoWork)
+	miniGame := canDoWork && config.CONFIG.Work.MiniGames
+
+	if miniGame {
+		if err := startShift(s, channelID, author, &work); err == errShiftPending {
+			*response = "Finish your current task first!"
+			return
+		} else if err != nil {
+			malm.Error("Could not send message! (Data not saved) %s", err)
+			return
+		}
+		*response = "Your task is waiting for you below!"
+	} else {
+		*response = createWorkMessageDescription(&user, &work, canDoWork)
+	}
 
 	work.StreakPostMsgAction()
 
-	user.Save()
+	if miniGame {
+		saveUsedStreakFreezes(&user, &work)
+	} else {
+		user.Save()
+	}
 	work.Save()
 
+	// The mini game sends the event when the task is answered
+	if canDoWork && !config.CONFIG.Work.MiniGames {
+		progress.Event(s, channelID, authorID, database.ActivityWork, 1)
+	}
+
 	var daily database.Daily
 	daily.GetDailyInfo(&user)
 
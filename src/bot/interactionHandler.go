@@ -6,6 +6,7 @@ import (
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands"
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/daily"
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/farming"
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/gambling"
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/work"
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/music"
 	"github.com/CarlFlo/malm"
@@ -22,7 +23,7 @@ func interactionHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	var commandIssuerID string
 
 	// Some messages, like music, does not have a user thumbnail (with their ID)
-	if strings.Contains(i.Message.Embeds[0].Thumbnail.URL, "#") {
+	if len(i.Message.Embeds) > 0 && i.Message.Embeds[0].Thumbnail != nil && strings.Contains(i.Message.Embeds[0].Thumbnail.URL, "#") {
 
 		commandIssuerID = strings.Split(i.Message.Embeds[0].Thumbnail.URL, "#")[1]
 		if interactionValidateInteractor(i, commandIssuerID) {
@@ -33,8 +34,10 @@ func interactionHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	}
 
 	switch i.MessageComponentData().CustomID {
-	case "BWT": // BWT: Buy Work Tool
-		work.BuyToolInteraction(commandIssuerID, &response, i.Interaction, msgEdit)
+	case "WTU0", "WTU1", "WTU2", "WTU3", "WTU4": // WTU: Work Tool Upgrade - Buys the tool or upgrades it to the next tier
+		work.ToolInteraction(commandIssuerID, i.MessageComponentData().CustomID, &response, i.Interaction, msgEdit)
+	case "WTR0", "WTR1", "WTR2", "WTR3", "WTR4": // WTR: Work Tool Repair
+		work.ToolInteraction(commandIssuerID, i.MessageComponentData().CustomID, &response, i.Interaction, msgEdit)
 		// Farming
 	case "BFP": // BFP: Buy Farm Plot
 		farming.BuyFarmPlotInteraction(commandIssuerID, &response, s, msgEdit)
@@ -42,25 +45,41 @@ func interactionHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		farming.FarmPlantInteraction(commandIssuerID, &response, i.Interaction, s, msgEdit)
 	case "FH": // FH: Farm Harvest
 		farming.HarvestInteraction(commandIssuerID, &response, s, msgEdit)
+	case "FPA": // FPA: Farm Plot Action - Harvests or uproots a single plot from the farm message using the menu
+		farming.FarmPlotActionInteraction(commandIssuerID, &response, i.Interaction, s, msgEdit)
+	case "FBU": // FBU: Farm Buy Upgrade - Buys an upgrade from the farm message using the menu
+		farming.BuyUpgradeInteraction(commandIssuerID, &response, i.Interaction, s, msgEdit)
 	case "FW": // FW: Farm Water
 		farming.WaterInteraction(commandIssuerID, &response, s, msgEdit)
-	case "FHELP":
+	case "FHELP", "GFHELP":
 		farming.FarmHelpInteractionEmbedCreate(&responseEmbed)
+	case "GFH", "GFW", "GBFP", "GFPC", "GFPA": // Guild farm versions of the farm interactions. Any member of the guild can use them
+		farming.GuildFarmInteraction(strings.TrimPrefix(i.MessageComponentData().CustomID, "G"), i.GuildID, i.Interaction.Member.User, &response, i.Interaction, s, msgEdit)
 		// Profile
 	case "RP": // RP: Refresh Profile
 		commands.ProfileRefreshInteraction(commandIssuerID, i.Interaction.Member.User, msgEdit)
 	case "PW": // PW: Profile Work - User worked from the profile message
-		work.DoWorkInteraction(commandIssuerID, &response, i.Interaction.Member.User, msgEdit)
+		work.DoWorkInteraction(commandIssuerID, &response, i.Interaction.Member.User, s, i.ChannelID, msgEdit)
+	case "WMG0", "WMG1", "WMG2", "WMG3": // WMG: Work Mini Game - The user answered the task given when working
+		work.MiniGameInteraction(s, commandIssuerID, i.MessageComponentData().CustomID, &response, msgEdit)
 	case "PD": // PD: Profile Daily - User did their daily from the profile message
-		daily.DoDailyInteraction(commandIssuerID, &response, i.Interaction.Member.User, msgEdit)
+		daily.DoDailyInteraction(commandIssuerID, &response, i.Interaction.Member.User, s, msgEdit)
+	case "BSF": // BSF: Buy Streak Freeze - User bought a streak freeze from the profile message
+		commands.BuyStreakFreezeInteraction(commandIssuerID, &response, i.Interaction.Member.User, msgEdit)
+	case "BJH", "BJS", "BJD": // BJ: Blackjack - The user hit, stood or doubled down in their game of blackjack
+		gambling.BlackjackInteraction(commandIssuerID, i.MessageComponentData().CustomID, &response, msgEdit)
+	case "DUA", "DUD", "DUP0", "DUP1", "DUP2": // DU: Duel - The user accepted, declined or picked in a duel. Both users can interact
+		gambling.DuelInteraction(i.Interaction.Member.User, i.MessageComponentData().CustomID, &response, msgEdit)
 	case "toggleSong":
 		music.PlayMusicInteraction(i.GuildID, i.Interaction.Member.User, &response)
 	case "stopSong":
-		malm.Info("Stopping song")
+		music.StopMusicInteraction(i.GuildID, i.Interaction.Member.User, &response)
 	case "clearQueue":
-		malm.Info("Cleaing queue")
+		music.ClearQueueInteraction(i.GuildID, i.Interaction.Member.User, &response)
 	case "nextSong":
-		malm.Info("Next song")
+		music.SkipMusicInteraction(i.GuildID, i.Interaction.Member.User, &response, msgEdit)
+	case "resumeMusic":
+		music.ResumeMusicInteraction(i.GuildID, i.ChannelID, i.Interaction.Member.User, &response)
 	default:
 		malm.Error("Invalid interaction: '%s'", i.MessageComponentData().CustomID)
 		return
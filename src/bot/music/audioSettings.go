@@ -0,0 +1,233 @@
+package music
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+	"github.com/jung-m/dca"
+)
+
+const (
+	minVolume = 0
+	maxVolume = 200
+	// Used when the config has no default volume or bitrate
+	defaultVolume  = 100
+	defaultBitrate = 64 // In kb/s
+	// dca uses 256 as the normal volume
+	dcaNormalVolume = 256
+)
+
+var errInvalidTimestamp = errors.New("invalid timestamp")
+
+// The audio filter presets. The value is passed on to ffmpeg
+// https://ffmpeg.org/ffmpeg-filters.html#Audio-Filters
+var filterPresets = map[string]string{
+	"bassboost": "bass=g=10",
+	"nightcore": "asetrate=48000*1.25,aresample=48000",
+	"normalize": "dynaudnorm",
+}
+
+// encodeOptions creates the encoder settings from the audio settings of the instance
+func (vi *VoiceInstance) encodeOptions() *dca.EncodeOptions {
+
+	settings := *dca.StdEncodeOptions
+	// Custom settings
+	settings.RawOutput = true
+	settings.Bitrate = vi.bitrate
+	settings.Volume = vi.volume * dcaNormalVolume / 100
+	settings.AudioFilter = filterPresets[vi.filter]
+	settings.StartTime = int(vi.startOffset.Seconds())
+	//settings.Application = "lowdelay"
+
+	return &settings
+}
+
+// getFilterNames returns the names of the filter presets in alphabetical order
+func getFilterNames() []string {
+	names := []string{}
+	for name := range filterPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseTimestamp parses timestamps such as "1:05:30", "3:25" or "45"
+func parseTimestamp(input string) (time.Duration, error) {
+
+	parts := strings.Split(input, ":")
+	if len(parts) > 3 {
+		return 0, errInvalidTimestamp
+	}
+
+	var duration time.Duration
+	for i, part := range parts {
+
+		value, err := strconv.Atoi(part)
+		if err != nil || value < 0 {
+			return 0, errInvalidTimestamp
+		}
+
+		// Only the first part can be 60 or over. "90" is 1 minute and 30 seconds but "1:90" is invalid
+		if i > 0 && value >= 60 {
+			return 0, errInvalidTimestamp
+		}
+
+		duration = duration*60 + time.Duration(value)
+	}
+
+	return duration * time.Second, nil
+}
+
+// getInstanceInSameChannel returns the instance for the guild, if the author is in the same voice channel as the bot.
+// Sends a message to the user and returns nil if not
+func getInstanceInSameChannel(m *discordgo.MessageCreate) *VoiceInstance {
+
+	if !isMusicEnabled() {
+		utils.SendMessageNeutral(m, "Music is currently disabled")
+		return nil
+	}
+
+	guildID, err := utils.GetGuild(m.ChannelID)
+	if err != nil {
+		malm.Error("Error getting guild ID: %s", err.Error())
+		return nil
+	}
+
+	vi := instances[guildID]
+	if vi == nil {
+		utils.SendMessageFailure(m, "Nothing is playing")
+		return nil
+	}
+
+	if vi.voice.ChannelID != utils.FindVoiceChannel(m.Author.ID) {
+		utils.SendMessageFailure(m, "You are not in the same voice channel as the bot")
+		return nil
+	}
+
+	return vi
+}
+
+// VolumeMusic changes the volume. Shows the current volume if no volume is given
+func VolumeMusic(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	vi := getInstanceInSameChannel(m)
+	if vi == nil {
+		return
+	}
+
+	if input.NumberOfArgsAre(0) {
+		utils.SendMessageNeutral(m, fmt.Sprintf("The volume is %d%%", vi.volume))
+		return
+	}
+
+	volume, err := strconv.Atoi(strings.TrimSuffix(input.GetArgs()[0], "%"))
+	if err != nil || volume < minVolume || volume > maxVolume {
+		utils.SendMessageFailure(m, fmt.Sprintf("The volume has to be a number between %d and %d", minVolume, maxVolume))
+		return
+	}
+
+	previous := vi.volume
+	vi.volume = volume
+
+	// The new volume is used by the next song if nothing is playing
+	if vi.playing && !vi.restartStream(vi.GetPlaybackPosition()) {
+		vi.volume = previous
+		utils.SendMessageFailure(m, "Hold on! The bot is loading the song")
+		return
+	}
+
+	utils.SendMessageSuccess(m, fmt.Sprintf("%s changed the volume to %d%%", m.Author.Username, volume))
+}
+
+// SeekMusic jumps to a position in the current song
+func SeekMusic(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	vi := getInstanceInSameChannel(m)
+	if vi == nil {
+		return
+	}
+
+	if !input.NumberOfArgsAre(1) {
+		utils.SendMessageFailure(m, "You need to provide a timestamp. Example: 1:30")
+		return
+	}
+
+	position, err := parseTimestamp(input.GetArgs()[0])
+	if err != nil {
+		utils.SendMessageFailure(m, "The timestamp is not valid. Example: 1:30")
+		return
+	}
+
+	song, err := vi.GetFirstInQueue()
+	if err != nil {
+		utils.SendMessageFailure(m, "Nothing is playing")
+		return
+	}
+
+	// The duration is unknown for some sources
+	if song.Duration > 0 && position >= song.Duration {
+		utils.SendMessageFailure(m, fmt.Sprintf("The song is only %s long", song.GetDuration()))
+		return
+	}
+
+	if !vi.restartStream(position) {
+		utils.SendMessageFailure(m, "Hold on! The bot is loading the song")
+		return
+	}
+
+	utils.SendMessageSuccess(m, fmt.Sprintf("%s skipped to %s", m.Author.Username, input.GetArgs()[0]))
+}
+
+// FilterMusic applies an audio filter preset. 'off' removes the filter
+func FilterMusic(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	vi := getInstanceInSameChannel(m)
+	if vi == nil {
+		return
+	}
+
+	filterList := strings.Join(getFilterNames(), ", ")
+
+	if input.NumberOfArgsAre(0) {
+		current := vi.filter
+		if len(current) == 0 {
+			current = "off"
+		}
+		utils.SendMessageNeutral(m, fmt.Sprintf("Current filter: %s\nAvailable filters: %s, off", current, filterList))
+		return
+	}
+
+	filter := input.GetArgsLowercase()[0]
+
+	if filter == "off" {
+		filter = ""
+	} else if _, ok := filterPresets[filter]; !ok {
+		utils.SendMessageFailure(m, fmt.Sprintf("Unknown filter. Available filters: %s, off", filterList))
+		return
+	}
+
+	previous := vi.filter
+	vi.filter = filter
+
+	// The new filter is used by the next song if nothing is playing
+	if vi.playing && !vi.restartStream(vi.GetPlaybackPosition()) {
+		vi.filter = previous
+		utils.SendMessageFailure(m, "Hold on! The bot is loading the song")
+		return
+	}
+
+	if len(filter) == 0 {
+		utils.SendMessageSuccess(m, fmt.Sprintf("%s removed the audio filter", m.Author.Username))
+		return
+	}
+	utils.SendMessageSuccess(m, fmt.Sprintf("%s applied the %s filter", m.Author.Username, filter))
+}
@@ -0,0 +1,49 @@
+package music
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+func TestParseTimestamp(t *testing.T) {
+
+	r1, err := parseTimestamp("1:30")
+	test.Validate(t, err, nil, "")
+	test.Validate(t, r1, 90*time.Second, "")
+
+	r2, _ := parseTimestamp("1:05:30")
+	test.Validate(t, r2, time.Hour+5*time.Minute+30*time.Second, "")
+
+	r3, _ := parseTimestamp("90")
+	test.Validate(t, r3, 90*time.Second, "Only seconds should be allowed")
+
+	for _, input := range []string{"", "1:90", "a:10", "-1", "1:2:3:4"} {
+		if _, err := parseTimestamp(input); err == nil {
+			t.Errorf("parseTimestamp(%q) should return an error", input)
+		}
+	}
+}
+
+func TestEncodeOptions(t *testing.T) {
+
+	vi := VoiceInstance{}
+	vi.volume = 50
+	vi.bitrate = 96
+	vi.filter = "bassboost"
+	vi.startOffset = 75 * time.Second
+
+	settings := vi.encodeOptions()
+	test.Validate(t, settings.Volume, 128, "50% volume should be half of dca's normal volume")
+	test.Validate(t, settings.Bitrate, 96, "")
+	test.Validate(t, settings.AudioFilter, filterPresets["bassboost"], "")
+	test.Validate(t, settings.StartTime, 75, "")
+	test.Validate(t, settings.Validate(), nil, "The settings should be valid")
+
+	vi.volume = maxVolume
+	vi.filter = ""
+	settings = vi.encodeOptions()
+	test.Validate(t, settings.Validate(), nil, "The max volume should be valid")
+	test.Validate(t, settings.AudioFilter, "", "")
+}
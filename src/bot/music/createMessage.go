@@ -106,8 +106,13 @@ func messageComponents(vi *VoiceInstance, c *[]discordgo.MessageComponent) {
 			Style:    2, // Gray
 		})
 
+		nextLabel := "Next"
+		if votes := vi.getSkipVotes(); votes > 0 {
+			nextLabel = fmt.Sprintf("Next (%d votes)", votes)
+		}
+
 		buttonRow.Components = append(buttonRow.Components, discordgo.Button{
-			Label:    "Next",
+			Label:    nextLabel,
 			CustomID: "nextSong",
 			Style:    1, // Default 'blurple'
 		})
@@ -163,12 +168,12 @@ func messageTitleAndDescription(vi *VoiceInstance) (string, string, string) {
 		for i := vi.GetQueueIndex() + 1; i < upTo; i++ {
 			song := vi.GetSongByIndex(i)
 
-			description = fmt.Sprintf("%s\n%s", description, song.Title)
+			description = fmt.Sprintf("%s\n%s (plays in %s)", description, song.Title, formatDuration(vi.TimeUntilSong(i)))
 		}
 
 	}
 
-	return title, description, song.GetYoutubeURL()
+	return title, description, song.GetURL()
 }
 
 func messageCreateFields(vi *VoiceInstance) []*discordgo.MessageEmbedField {
@@ -193,7 +198,7 @@ func messageCreateFields(vi *VoiceInstance) []*discordgo.MessageEmbedField {
 func messageThumbnail(vi *VoiceInstance) *discordgo.MessageEmbedThumbnail {
 
 	song, err := vi.GetFirstInQueue()
-	if err != nil {
+	if err != nil || len(song.Thumbnail) == 0 {
 		return nil
 	}
 
@@ -221,6 +226,8 @@ func messageFooter(vi *VoiceInstance) *discordgo.MessageEmbedFooter {
 		text += fmt.Sprintf("\nCurrent song duration: %s", song.GetDuration())
 	}
 
+	text += fmt.Sprintf("\nTotal queue time: %s", formatDuration(vi.GetQueueDuration()))
+
 	return &discordgo.MessageEmbedFooter{
 		Text: text,
 	}
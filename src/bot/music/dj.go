@@ -0,0 +1,153 @@
+package music
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/context"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/bwmarrin/discordgo"
+)
+
+// On busy servers only DJs can control the music freely.
+// Other users can skip their own songs and vote to skip the songs of others
+
+// skipVotes keeps track of the users that have voted to skip the current song
+type skipVotes struct {
+	mutex  sync.Mutex
+	voters map[string]bool
+}
+
+// isDJ returns true if the user has the DJ role, is an administrator or if the DJ role is turned off
+func isDJ(guildID, userID string) bool {
+
+	if len(config.CONFIG.Music.DJRoleName) == 0 || userID == config.CONFIG.OwnerID {
+		return true
+	}
+
+	guild, err := context.SESSION.State.Guild(guildID)
+	if err != nil {
+		return false
+	}
+
+	if guild.OwnerID == userID {
+		return true
+	}
+
+	member, err := context.SESSION.State.Member(guildID, userID)
+	if err != nil {
+		if member, err = context.SESSION.GuildMember(guildID, userID); err != nil {
+			return false
+		}
+	}
+
+	for _, roleID := range member.Roles {
+		for _, role := range guild.Roles {
+			if role.ID != roleID {
+				continue
+			}
+			if strings.EqualFold(role.Name, config.CONFIG.Music.DJRoleName) || role.Permissions&discordgo.PermissionAdministrator != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// canControlMusic returns true if the user is a DJ or is alone with the bot in the voice channel
+func canControlMusic(vi *VoiceInstance, userID string) bool {
+	return isDJ(vi.GetGuildID(), userID) || utils.CountHumansInVoiceChannel(vi.GetGuildID(), vi.voice.ChannelID) <= 1
+}
+
+// addSkipVote adds the users vote to skip the current song.
+// Returns the number of votes, the number of votes needed and if the vote passed.
+// A majority of the listeners have to vote for the song to be skipped
+func (vi *VoiceInstance) addSkipVote(userID string, listeners int) (int, int, bool) {
+	vi.skipVotes.mutex.Lock()
+	defer vi.skipVotes.mutex.Unlock()
+
+	if vi.skipVotes.voters == nil {
+		vi.skipVotes.voters = map[string]bool{}
+	}
+	vi.skipVotes.voters[userID] = true
+
+	votes := len(vi.skipVotes.voters)
+	needed := listeners/2 + 1
+
+	return votes, needed, votes >= needed
+}
+
+// getSkipVotes returns the number of votes to skip the current song
+func (vi *VoiceInstance) getSkipVotes() int {
+	vi.skipVotes.mutex.Lock()
+	defer vi.skipVotes.mutex.Unlock()
+	return len(vi.skipVotes.voters)
+}
+
+// resetSkipVotes removes all the votes. Called when a new song starts
+func (vi *VoiceInstance) resetSkipVotes() {
+	vi.skipVotes.mutex.Lock()
+	defer vi.skipVotes.mutex.Unlock()
+	vi.skipVotes.voters = nil
+}
+
+// requestSkip skips the song if the user is a DJ or requested the song, otherwise the user votes to skip it.
+// Returns the response for the user and true if it went well
+func requestSkip(vi *VoiceInstance, user *discordgo.User) (string, bool) {
+
+	song, err := vi.GetFirstInQueue()
+	if err != nil || !vi.IsPlaying() {
+		return "There is no song to skip", false
+	}
+
+	if vi.voice.ChannelID != utils.FindVoiceChannel(user.ID) {
+		return "You are not in the same voice channel as the bot", false
+	}
+
+	if song.User == user.ID || canControlMusic(vi, user.ID) {
+		vi.Skip()
+		return fmt.Sprintf("%s skipped the song", user.Username), true
+	}
+
+	listeners := utils.CountHumansInVoiceChannel(vi.GetGuildID(), vi.voice.ChannelID)
+	votes, needed, passed := vi.addSkipVote(user.ID, listeners)
+
+	if passed {
+		vi.Skip()
+		return fmt.Sprintf("The vote passed (%d/%d). Skipped the song", votes, needed), true
+	}
+
+	return fmt.Sprintf("%s voted to skip the song (%d/%d votes)", user.Username, votes, needed), true
+}
+
+// requestStop stops the music and leaves the voice channel if the user is allowed to
+func requestStop(vi *VoiceInstance, user *discordgo.User) (string, bool) {
+
+	if !canControlMusic(vi, user.ID) {
+		return fmt.Sprintf("Only users with the '%s' role can stop the music", config.CONFIG.Music.DJRoleName), false
+	}
+
+	leaveVoice(vi)
+	return "", true
+}
+
+// requestClearQueue clears the queue if the user is a DJ, otherwise only the users own songs are removed
+func requestClearQueue(vi *VoiceInstance, user *discordgo.User) (string, bool) {
+
+	if canControlMusic(vi, user.ID) {
+		vi.ClearQueue()
+		vi.saveSession()
+		vi.Stop() // Should it stop the bot?
+		return "", true
+	}
+
+	removed := vi.RemoveSongsByUser(user.ID)
+	vi.saveSession()
+	if removed == 0 {
+		return "You have no songs in the queue. Only DJs can clear the songs of others", false
+	}
+
+	return fmt.Sprintf("Removed %d of your songs from the queue", removed), true
+}
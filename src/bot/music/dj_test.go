@@ -0,0 +1,30 @@
+package music
+
+import (
+	"testing"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+func TestSkipVotes(t *testing.T) {
+	vi := VoiceInstance{}
+
+	votes, needed, passed := vi.addSkipVote("a", 4)
+	test.Validate(t, votes, 1, "There should be 1 vote")
+	test.Validate(t, needed, 3, "3 votes should be needed with 4 listeners")
+	test.Validate(t, passed, false, "The vote should not pass with 1 of 3 votes")
+
+	// The same user can only vote once
+	votes, _, _ = vi.addSkipVote("a", 4)
+	test.Validate(t, votes, 1, "A user should only be able to vote once")
+
+	vi.addSkipVote("b", 4)
+	_, _, passed = vi.addSkipVote("c", 4)
+	test.Validate(t, passed, true, "The vote should pass with 3 of 3 votes")
+
+	vi.resetSkipVotes()
+	test.Validate(t, vi.getSkipVotes(), 0, "There should be no votes after resetSkipVotes()")
+
+	_, _, passed = vi.addSkipVote("a", 1)
+	test.Validate(t, passed, true, "The vote should pass when the user is the only listener")
+}
@@ -0,0 +1,112 @@
+package music
+
+import (
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/context"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+// The bot leaves the voice channel if nothing is played or nobody is listening for a while
+
+// startIdleTimer starts the countdown for leaving the voice channel.
+// Does nothing if the countdown already is running or if it is turned off in the config
+func (vi *VoiceInstance) startIdleTimer() {
+
+	timeout := time.Duration(config.CONFIG.Music.IdleDisconnectMinutes) * time.Minute
+	if timeout <= 0 {
+		return
+	}
+
+	vi.idleMutex.Lock()
+	defer vi.idleMutex.Unlock()
+
+	if vi.idleTimer != nil {
+		return
+	}
+
+	vi.idleTimer = time.AfterFunc(timeout, vi.idleTimeout)
+}
+
+// stopIdleTimer stops the countdown for leaving the voice channel
+func (vi *VoiceInstance) stopIdleTimer() {
+	vi.idleMutex.Lock()
+	defer vi.idleMutex.Unlock()
+
+	if vi.idleTimer != nil {
+		vi.idleTimer.Stop()
+		vi.idleTimer = nil
+	}
+}
+
+// idleTimeout runs when the countdown has finished. Leaves the voice channel and removes the instance
+func (vi *VoiceInstance) idleTimeout() {
+
+	vi.idleMutex.Lock()
+	vi.idleTimer = nil
+	vi.idleMutex.Unlock()
+
+	// The instance could have been stopped by a user while the timer was running
+	if !leaveVoice(vi) {
+		return
+	}
+
+	malm.Debug("Left voice channel in guild '%s' due to inactivity", vi.GetGuildID())
+	vi.updateOverviewMessageLeft("Left the voice channel due to inactivity")
+}
+
+// updateOverviewMessageLeft edits the overview message one last time, removing the buttons
+func (vi *VoiceInstance) updateOverviewMessageLeft(reason string) {
+
+	if len(vi.GetMessageID()) == 0 {
+		return
+	}
+
+	msgEdit := &discordgo.MessageEdit{
+		Channel: vi.GetChannelID(),
+		ID:      vi.GetMessageID(),
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       "Nothing to play",
+				Description: reason,
+				Color:       config.CONFIG.Colors.Neutral,
+				Author:      messageAuthor(vi),
+			},
+		},
+		Components: []discordgo.MessageComponent{},
+	}
+
+	if _, err := context.SESSION.ChannelMessageEditComplex(msgEdit); err != nil {
+		malm.Error("cannot create message edit, error: %s", err)
+	}
+}
+
+// VoiceStateUpdate is called when someone joins, leaves or moves between voice channels.
+// Starts the idle countdown when everyone has left the bot's voice channel
+func VoiceStateUpdate(s *discordgo.Session, vsu *discordgo.VoiceStateUpdate) {
+
+	musicMutex.Lock()
+	vi := instances[vsu.GuildID]
+	musicMutex.Unlock()
+
+	if vi == nil || vi.voice == nil {
+		return
+	}
+
+	// The bot was disconnected from the voice channel by someone else
+	if vsu.UserID == s.State.User.ID && len(vsu.ChannelID) == 0 {
+		if leaveVoice(vi) {
+			vi.updateOverviewMessageLeft("Disconnected from the voice channel")
+		}
+		return
+	}
+
+	if utils.CountHumansInVoiceChannel(vsu.GuildID, vi.voice.ChannelID) == 0 {
+		vi.startIdleTimer()
+	} else if vi.IsPlaying() {
+		vi.stopIdleTimer()
+	}
+}
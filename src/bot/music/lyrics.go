@@ -0,0 +1,126 @@
+package music
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/context"
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+var errNoLyrics = errors.New("no lyrics found for the song")
+
+// Discord does not allow longer embed descriptions
+const maxLyricsLength = 4096
+
+// LyricsProvider finds the lyrics for a song
+type LyricsProvider interface {
+	Name() string
+	// Lyrics returns errNoLyrics if the provider does not have the lyrics for the song
+	Lyrics(song *Song) (string, error)
+}
+
+// The providers are asked in order until one of them has the lyrics
+var lyricsProviders []LyricsProvider
+
+// initializeLyricsProviders registers the lyrics providers that are enabled in the config
+func initializeLyricsProviders() {
+
+	lyricsProviders = []LyricsProvider{}
+
+	if len(config.CONFIG.Music.LyricsDirectory) > 0 {
+		if _, err := os.Stat(config.CONFIG.Music.LyricsDirectory); err != nil {
+			malm.Warn("Could not find the lyrics directory '%s'. Local lyrics disabled", config.CONFIG.Music.LyricsDirectory)
+		} else {
+			lyricsProviders = append(lyricsProviders, newLocalLyricsProvider(config.CONFIG.Music.LyricsDirectory))
+		}
+	}
+}
+
+// findLyrics asks the providers for the lyrics of the song
+func findLyrics(song *Song) (string, error) {
+
+	for _, provider := range lyricsProviders {
+		lyrics, err := provider.Lyrics(song)
+		if err == errNoLyrics {
+			continue
+		} else if err != nil {
+			malm.Error("Lyrics provider '%s' failed: %s", provider.Name(), err)
+			continue
+		}
+		return lyrics, nil
+	}
+	return "", errNoLyrics
+}
+
+// LyricsMusic shows the lyrics for the current song, or for the song title given as input
+func LyricsMusic(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	if !isMusicEnabled() {
+		utils.SendMessageNeutral(m, "Music is currently disabled")
+		return
+	}
+
+	if len(lyricsProviders) == 0 {
+		utils.SendMessageNeutral(m, "Lyrics are currently disabled")
+		return
+	}
+
+	var song Song
+
+	if input.NumberOfArgsAre(0) {
+		guildID, err := utils.GetGuild(m.ChannelID)
+		if err != nil {
+			malm.Error("Error getting guild ID: %s", err.Error())
+			return
+		}
+
+		vi := instances[guildID]
+		if vi == nil {
+			utils.SendMessageFailure(m, "Nothing is playing. Provide the name of a song instead")
+			return
+		}
+
+		if song, err = vi.GetFirstInQueue(); err != nil {
+			utils.SendMessageFailure(m, "Nothing is playing. Provide the name of a song instead")
+			return
+		}
+	} else {
+		song.Title = strings.Join(input.GetArgs(), " ")
+	}
+
+	lyrics, err := findLyrics(&song)
+	if err != nil {
+		utils.SendMessageFailure(m, "Could not find any lyrics for ``"+song.Title+"``")
+		return
+	}
+
+	lyrics = truncateLyrics(lyrics)
+
+	embed := &discordgo.MessageEmbed{
+		Title:       song.Title,
+		URL:         song.GetURL(),
+		Description: lyrics,
+		Color:       config.CONFIG.Colors.Neutral,
+		Author:      &discordgo.MessageEmbedAuthor{Name: "Lyrics"},
+	}
+
+	if _, err := context.SESSION.ChannelMessageSendEmbed(m.ChannelID, embed); err != nil {
+		malm.Error("Could not send message! %s", err)
+	}
+}
+
+// truncateLyrics shortens the lyrics to fit in an embed. Discord counts the characters, not the bytes
+func truncateLyrics(lyrics string) string {
+
+	characters := []rune(lyrics)
+	if len(characters) <= maxLyricsLength {
+		return lyrics
+	}
+	return string(characters[:maxLyricsLength-3]) + "..."
+}
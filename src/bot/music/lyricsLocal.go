@@ -0,0 +1,100 @@
+package music
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Matches the timestamps in .lrc files. [01:23.45]
+var lrcTimestampRegex = regexp.MustCompile(`\[\d+:\d+(?:[.:]\d+)?\]`)
+
+var lyricsExtensions = []string{".txt", ".lrc"}
+
+// localLyricsProvider reads lyrics from text files in the lyrics directory set in the config.
+// The file name has to be the title of the song, e.g. "Heat Waves.txt"
+type localLyricsProvider struct {
+	directory string
+}
+
+func newLocalLyricsProvider(directory string) *localLyricsProvider {
+	return &localLyricsProvider{directory: directory}
+}
+
+func (p *localLyricsProvider) Name() string {
+	return "local"
+}
+
+func (p *localLyricsProvider) Lyrics(song *Song) (string, error) {
+
+	entries, err := os.ReadDir(p.directory)
+	if err != nil {
+		return "", err
+	}
+
+	title := normalizeTitle(song.Title)
+
+	for _, entry := range entries {
+		extension := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || !isLyricsExtension(extension) {
+			continue
+		}
+
+		if normalizeTitle(strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))) != title {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(p.directory, entry.Name()))
+		if err != nil {
+			return "", err
+		}
+
+		lyrics := string(content)
+		if extension == ".lrc" {
+			lyrics = stripLRCTimestamps(lyrics)
+		}
+		return strings.TrimSpace(lyrics), nil
+	}
+
+	return "", errNoLyrics
+}
+
+func isLyricsExtension(extension string) bool {
+	for _, e := range lyricsExtensions {
+		if e == extension {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeTitle makes titles comparable by only keeping lowercase letters and numbers
+// "Heat Waves!" and "heat-waves" are treated as the same title
+func normalizeTitle(title string) string {
+
+	var builder strings.Builder
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// stripLRCTimestamps removes the timestamps and the metadata tags from the lyrics in a .lrc file
+func stripLRCTimestamps(lyrics string) string {
+
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(lyrics, "\r\n", "\n"), "\n") {
+		stripped := lrcTimestampRegex.ReplaceAllString(line, "")
+
+		// Metadata tags, such as [ar:Artist]
+		if stripped == line && strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			continue
+		}
+		lines = append(lines, strings.TrimSpace(stripped))
+	}
+	return strings.Join(lines, "\n")
+}
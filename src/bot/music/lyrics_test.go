@@ -0,0 +1,45 @@
+package music
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+func TestLocalLyricsProvider(t *testing.T) {
+
+	directory := t.TempDir()
+	if err := os.WriteFile(filepath.Join(directory, "Heat Waves.txt"), []byte("Road shimmer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(directory, "Colors.lrc"), []byte("[ar:Artist]\n[00:01.00]First line\n[00:05.50]Second line"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := newLocalLyricsProvider(directory)
+
+	lyrics, err := p.Lyrics(&Song{Title: "heat waves!"})
+	test.Validate(t, err, nil, "Lyrics() should find the file even if the title is written differently")
+	test.Validate(t, lyrics, "Road shimmer", "Lyrics() should return the content of the file")
+
+	lyrics, _ = p.Lyrics(&Song{Title: "Colors"})
+	test.Validate(t, lyrics, "First line\nSecond line", "The timestamps and tags in .lrc files should be removed")
+
+	_, err = p.Lyrics(&Song{Title: "Missing"})
+	test.Validate(t, err, errNoLyrics, "Lyrics() should return errNoLyrics when there is no file")
+}
+
+func TestTruncateLyrics(t *testing.T) {
+
+	short := strings.Repeat("å", maxLyricsLength)
+	test.Validate(t, truncateLyrics(short), short, "Lyrics within the limit should not be changed")
+
+	long := truncateLyrics(strings.Repeat("å", maxLyricsLength+1))
+	test.Validate(t, utf8.RuneCountInString(long), maxLyricsLength, "The lyrics should be cut to the limit")
+	test.Validate(t, utf8.ValidString(long), true, "The lyrics should not be cut in the middle of a character")
+	test.Validate(t, strings.HasSuffix(long, "..."), true, "The cut lyrics should end with '...'")
+}
@@ -1,13 +1,16 @@
 package music
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/commands/progress"
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/context"
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
 	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
 	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
 	"github.com/CarlFlo/malm"
 	"github.com/bwmarrin/discordgo"
@@ -31,14 +34,8 @@ var instances = map[string]*VoiceInstance{}
 */
 
 var (
-	musicMutex           sync.Mutex
-	songSignal           chan *VoiceInstance
-	youtubeAPIKeyPresent bool
-)
-
-const (
-	youtubePattern string = `(youtube\.com\/watch\?v=)`
-	urlPattern     string = `[-a-zA-Z0-9@:%._\+~#=]{1,256}\.[a-zA-Z0-9()]{1,6}\b([-a-zA-Z0-9()@:%_\+.~#?&//=]*)`
+	musicMutex sync.Mutex
+	songSignal chan *VoiceInstance
 )
 
 func Initialize() {
@@ -52,10 +49,11 @@ func Initialize() {
 // InitializeMusic initializes the music goroutine and channel signal
 func InitializeMusic() error {
 
-	if err := utils.ValidateYoutubeAPIKey(); err != nil {
-		youtubeAPIKeyPresent = false
-		return err
+	initializeResolvers()
+	if !isMusicEnabled() {
+		return errors.New("no audio sources are enabled")
 	}
+	initializeLyricsProviders()
 
 	songSignal = make(chan *VoiceInstance)
 
@@ -65,7 +63,6 @@ func InitializeMusic() error {
 		}
 	}()
 
-	youtubeAPIKeyPresent = true
 	return nil
 }
 
@@ -114,16 +111,48 @@ func PlayMusic(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.
 	inputText := strings.Join(input.GetArgs(), " ")
 
 	err = parseMusicInput(m, inputText, &song)
-	if err != nil {
+	if err == errUnsupportedSource {
+		utils.SendMessageFailure(m, "That source is not supported")
+		return
+	} else if err == errSongTooLong {
+		utils.SendMessageFailure(m, fmt.Sprintf("The song is too long! The maximum duration for a song is %d minutes", config.CONFIG.Music.MaxSongLengthMinutes))
+		return
+	} else if err == errUnknownDuration {
+		utils.SendMessageFailure(m, "Could not find the length of the song")
+		return
+	} else if errors.Is(err, errBlockedAddress) {
+		utils.SendMessageFailure(m, "Links to private or local addresses are not allowed")
+		return
+	} else if err != nil {
 		malm.Error("%s", err)
-		utils.SendMessageFailure(m, fmt.Sprintf("Something went wrong when getting the song. The maximum duration for a song is %d", config.CONFIG.Music.MaxSongLengthMinutes))
+		utils.SendMessageFailure(m, "Something went wrong when getting the song")
 		return
 	}
 
+	// Calculated before the song is added so its own duration is not included
+	playsIn := vi.GetQueueDuration()
+
 	// Add the song to the queue
 	vi.AddToQueue(song)
+	vi.saveSession()
 
-	utils.SendMessageNeutral(m, fmt.Sprintf("%s added the song ``%s`` to the queue (%s)", m.Author.Username, song.Title, song.duration))
+	// The song could be the next one to play
+	if vi.IsPlaying() {
+		vi.prefetchNextSong()
+	}
+
+	addedMessage := fmt.Sprintf("%s added the song ``%s`` to the queue (%s)", m.Author.Username, song.Title, song.GetDuration())
+	if playsIn > 0 {
+		addedMessage += fmt.Sprintf("\nEstimated time until playing: %s", formatDuration(playsIn))
+	}
+	utils.SendMessageNeutral(m, addedMessage)
+
+	// Counted for the achievements
+	var user database.User
+	user.QueryUserByDiscordID(m.Author.ID)
+	user.SongsQueued++
+	user.Save()
+	progress.Event(s, m.ChannelID, m.Author.ID, database.ActivityMusic, 1)
 
 	complexMessage := &discordgo.MessageSend{}
 
@@ -167,7 +196,9 @@ func StopMusic(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.
 		return
 	}
 
-	leaveVoice(vi, m)
+	if response, ok := requestStop(vi, m.Author); !ok {
+		utils.SendMessageFailure(m, response)
+	}
 }
 
 func SkipMusic(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
@@ -188,14 +219,15 @@ func SkipMusic(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.
 		return
 	}
 
-	if vi.Skip() {
-		utils.SendMessageSuccess(m, "Skipped the song")
+	if response, ok := requestSkip(vi, m.Author); ok {
+		utils.SendMessageSuccess(m, response)
 	} else {
-		utils.SendMessageFailure(m, "There is no song to skip")
+		utils.SendMessageFailure(m, response)
 	}
 }
 
 // ClearQueueMusic clears the queue. Does not include the current song or previus songs
+// Users that are not DJs can only remove their own songs
 func ClearQueueMusic(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
 	if !isMusicEnabled() {
 		utils.SendMessageNeutral(m, "Music is currently disabled")
@@ -214,8 +246,37 @@ func ClearQueueMusic(s *discordgo.Session, m *discordgo.MessageCreate, input *st
 		return
 	}
 
-	vi.ClearQueue()
-	vi.Stop() // Should it stop the bot?
+	response, ok := requestClearQueue(vi, m.Author)
+	if !ok {
+		utils.SendMessageFailure(m, response)
+	} else if len(response) > 0 {
+		utils.SendMessageSuccess(m, response)
+	}
+}
+
+// ResumeMusic unpauses the music or continues the music that was playing before the bot was restarted
+func ResumeMusic(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+	if !isMusicEnabled() {
+		utils.SendMessageNeutral(m, "Music is currently disabled")
+		return
+	}
+
+	guildID, err := utils.GetGuild(m.ChannelID)
+	if err != nil {
+		malm.Error("Error getting guild ID: %s", err.Error())
+		return
+	}
+
+	if vi := instances[guildID]; vi != nil {
+		if vi.IsPaused() {
+			vi.PauseToggle()
+		}
+		return
+	}
+
+	if response, ok := resumeSession(guildID, m.Author, m.ChannelID); !ok {
+		utils.SendMessageFailure(m, response)
+	}
 }
 
 // PauseMusic pauyses the music
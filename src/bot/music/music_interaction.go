@@ -32,3 +32,47 @@ func PlayMusicInteraction(guildID string, author *discordgo.User, response *stri
 
 	vi.PauseToggle()
 }
+
+func StopMusicInteraction(guildID string, author *discordgo.User, response *string) {
+
+	vi := instances[guildID]
+	if !isMusicEnabled() || vi == nil {
+		return
+	}
+
+	*response, _ = requestStop(vi, author)
+}
+
+func ClearQueueInteraction(guildID string, author *discordgo.User, response *string) {
+
+	vi := instances[guildID]
+	if !isMusicEnabled() || vi == nil {
+		return
+	}
+
+	*response, _ = requestClearQueue(vi, author)
+}
+
+// SkipMusicInteraction skips the song or adds a vote to skip it. The message is updated to show the votes
+func SkipMusicInteraction(guildID string, author *discordgo.User, response *string, me *discordgo.MessageEdit) {
+
+	vi := instances[guildID]
+	if !isMusicEnabled() || vi == nil {
+		return
+	}
+
+	*response, _ = requestSkip(vi, author)
+
+	CreateMusicOverviewMessage(vi.GetChannelID(), me)
+}
+
+// ResumeMusicInteraction continues the music that was playing before the bot was restarted
+func ResumeMusicInteraction(guildID, channelID string, author *discordgo.User, response *string) {
+
+	if !isMusicEnabled() {
+		*response = "Music is currently disabled"
+		return
+	}
+
+	*response, _ = resumeSession(guildID, author, channelID)
+}
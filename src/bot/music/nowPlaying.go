@@ -0,0 +1,151 @@
+package music
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/context"
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/structs"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+const progressBarLength = 20
+
+// NowPlayingMusic shows details about the current song, such as how far into the song it is and who requested it
+func NowPlayingMusic(s *discordgo.Session, m *discordgo.MessageCreate, input *structs.CmdInput) {
+
+	if !isMusicEnabled() {
+		utils.SendMessageNeutral(m, "Music is currently disabled")
+		return
+	}
+
+	guildID, err := utils.GetGuild(m.ChannelID)
+	if err != nil {
+		malm.Error("Error getting guild ID: %s", err.Error())
+		return
+	}
+
+	vi := instances[guildID]
+	if vi == nil {
+		utils.SendMessageNeutral(m, "Nothing is playing")
+		return
+	}
+
+	song, err := vi.GetFirstInQueue()
+	if err != nil {
+		utils.SendMessageNeutral(m, "Nothing is playing")
+		return
+	}
+
+	if _, err := context.SESSION.ChannelMessageSendEmbed(m.ChannelID, createNowPlayingEmbed(vi, &song)); err != nil {
+		malm.Error("Could not send message! %s", err)
+	}
+}
+
+func createNowPlayingEmbed(vi *VoiceInstance, song *Song) *discordgo.MessageEmbed {
+
+	elapsed := vi.GetPlaybackPosition()
+
+	status := config.CONFIG.Emojis.MusicPlaying
+	if vi.IsPaused() {
+		status = config.CONFIG.Emojis.MusicPaused
+	}
+
+	description := fmt.Sprintf("%s %s\n``%s / %s``", status, progressBar(elapsed, song.Duration, progressBarLength), formatTimestamp(elapsed), formatTimestamp(song.Duration))
+
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:   "Requested by",
+			Value:  fmt.Sprintf("<@%s>", song.User),
+			Inline: true,
+		},
+		{
+			Name:   "Source",
+			Value:  song.ChannelName,
+			Inline: true,
+		},
+	}
+
+	if vi.IsLooping() {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "Looping",
+			Value:  "The song will be played again",
+			Inline: true,
+		})
+	}
+
+	if next, ok := vi.getNextSong(); ok {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  "Up Next",
+			Value: fmt.Sprintf("%s (%s)", next.Title, next.GetDuration()),
+		})
+	}
+
+	for _, field := range fields {
+		if len(field.Value) == 0 {
+			field.Value = "-"
+		}
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       song.Title,
+		URL:         song.GetURL(),
+		Description: description,
+		Color:       config.CONFIG.Colors.Neutral,
+		Thumbnail:   messageThumbnail(vi),
+		Author:      &discordgo.MessageEmbedAuthor{Name: "Now Playing"},
+		Fields:      fields,
+	}
+}
+
+// getNextSong returns the song after the current one. False if there is none
+func (vi *VoiceInstance) getNextSong() (Song, bool) {
+	vi.queueMutex.Lock()
+	defer vi.queueMutex.Unlock()
+
+	next := vi.queueIndex + 1
+	if next >= len(vi.queue) {
+		return Song{}, false
+	}
+	return vi.queue[next], true
+}
+
+// progressBar creates a text progress bar showing how far into the song the playback is
+// ▬▬▬▬🔘▬▬▬▬▬
+func progressBar(elapsed, total time.Duration, length int) string {
+
+	position := 0
+	if total > 0 {
+		position = int(float64(elapsed) / float64(total) * float64(length))
+	}
+
+	if position < 0 {
+		position = 0
+	} else if position >= length {
+		position = length - 1
+	}
+
+	return strings.Repeat("▬", position) + "🔘" + strings.Repeat("▬", length-position-1)
+}
+
+// formatTimestamp formats the duration like a media player would
+// 1h2m3s -> 1:02:03, 2m3s -> 2:03
+func formatTimestamp(duration time.Duration) string {
+
+	duration = duration.Round(time.Second)
+
+	hours := duration / time.Hour
+	duration -= hours * time.Hour
+	minutes := duration / time.Minute
+	duration -= minutes * time.Minute
+	seconds := duration / time.Second
+
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, seconds)
+}
@@ -0,0 +1,21 @@
+package music
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+func TestProgressBar(t *testing.T) {
+	test.Validate(t, progressBar(0, time.Minute, 5), "🔘▬▬▬▬", "The progress bar should start at the beginning")
+	test.Validate(t, progressBar(30*time.Second, time.Minute, 5), "▬▬🔘▬▬", "The progress bar should be in the middle")
+	test.Validate(t, progressBar(time.Minute, time.Minute, 5), "▬▬▬▬🔘", "The progress bar should not go past the end")
+	test.Validate(t, progressBar(time.Minute, 0, 5), "🔘▬▬▬▬", "Songs without a duration should show the start")
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	test.Validate(t, formatTimestamp(0), "0:00", "An empty duration should be 0:00")
+	test.Validate(t, formatTimestamp(2*time.Minute+3*time.Second), "2:03", "The seconds should be padded")
+	test.Validate(t, formatTimestamp(time.Hour+2*time.Minute+3*time.Second), "1:02:03", "The hours should be shown")
+}
@@ -0,0 +1,155 @@
+package music
+
+import (
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/CarlFlo/malm"
+)
+
+// Resolving the stream URL of a youtube video takes a couple of seconds.
+// The URL for the next song in the queue is resolved while the current song is playing,
+// so there is no silence between songs
+
+const (
+	// Used when the stream URL does not say when it expires. Youtube links expire after 6 hours
+	defaultStreamURLLifetime = 5 * time.Hour
+	// Stream URLs expiring within this time are resolved again, so they won't expire mid song
+	streamURLExpiryMargin = 30 * time.Minute
+)
+
+type cachedStreamURL struct {
+	url       string
+	expiresAt time.Time
+}
+
+// inflightStreamURL lets several goroutines wait for the same stream URL to be resolved
+type inflightStreamURL struct {
+	done chan struct{}
+	url  string
+	err  error
+}
+
+type streamURLCache struct {
+	mutex    sync.Mutex
+	entries  map[string]cachedStreamURL
+	inflight map[string]*inflightStreamURL
+	now      func() time.Time
+}
+
+func newStreamURLCache(now func() time.Time) *streamURLCache {
+	return &streamURLCache{
+		entries:  map[string]cachedStreamURL{},
+		inflight: map[string]*inflightStreamURL{},
+		now:      now,
+	}
+}
+
+var streamURLs = newStreamURLCache(time.Now)
+
+// get returns the cached stream URL for the key if it has not expired.
+// Otherwise resolve is called and the result is cached.
+// If the same key already is being resolved, the result of that call is used instead
+func (c *streamURLCache) get(key string, resolve func() (string, error)) (string, error) {
+
+	c.mutex.Lock()
+
+	if entry, ok := c.entries[key]; ok {
+		if c.now().Add(streamURLExpiryMargin).Before(entry.expiresAt) {
+			c.mutex.Unlock()
+			return entry.url, nil
+		}
+		delete(c.entries, key)
+	}
+
+	if call, ok := c.inflight[key]; ok {
+		c.mutex.Unlock()
+		<-call.done
+		return call.url, call.err
+	}
+
+	call := &inflightStreamURL{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mutex.Unlock()
+
+	call.url, call.err = resolve()
+
+	c.mutex.Lock()
+	delete(c.inflight, key)
+	if call.err == nil {
+		c.entries[key] = cachedStreamURL{
+			url:       call.url,
+			expiresAt: streamURLExpiresAt(call.url, c.now()),
+		}
+	}
+	c.mutex.Unlock()
+
+	close(call.done)
+	return call.url, call.err
+}
+
+// removeExpired removes all the expired entries. Called when a new song starts so the cache does not grow forever
+func (c *streamURLCache) removeExpired() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for key, entry := range c.entries {
+		if !c.now().Add(streamURLExpiryMargin).Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// streamURLExpiresAt reads when the stream URL expires.
+// Youtube stream URLs contain the unix time they expire at in the 'expire' query parameter
+func streamURLExpiresAt(streamURL string, now time.Time) time.Time {
+
+	parsedURL, err := url.Parse(streamURL)
+	if err != nil {
+		return now.Add(defaultStreamURLLifetime)
+	}
+
+	expire, err := strconv.ParseInt(parsedURL.Query().Get("expire"), 10, 64)
+	if err != nil {
+		return now.Add(defaultStreamURLLifetime)
+	}
+
+	return time.Unix(expire, 0)
+}
+
+// resolveStreamURL returns the URL ffmpeg will stream the song from. Uses the cache when possible
+func resolveStreamURL(song *Song) (string, error) {
+
+	resolver := getResolverByName(song.Source)
+	if resolver == nil {
+		return "", errUnknownSource
+	}
+
+	return streamURLs.get(song.cacheKey(), func() (string, error) {
+		return resolver.StreamURL(song)
+	})
+}
+
+// prefetchNextSong resolves the stream URL of the next song in the queue in the background
+func (vi *VoiceInstance) prefetchNextSong() {
+
+	vi.queueMutex.Lock()
+	next := vi.queueIndex + 1
+	if vi.IsLooping() {
+		next = vi.queueIndex
+	}
+	if next >= len(vi.queue) {
+		vi.queueMutex.Unlock()
+		return
+	}
+	song := vi.queue[next]
+	vi.queueMutex.Unlock()
+
+	go func() {
+		if _, err := resolveStreamURL(&song); err != nil {
+			malm.Warn("Could not prefetch the song '%s': %s", song.Title, err)
+		}
+	}()
+}
@@ -0,0 +1,54 @@
+package music
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+func TestStreamURLExpiresAt(t *testing.T) {
+
+	now := time.Unix(1000, 0)
+
+	expiresAt := streamURLExpiresAt("https://example.googlevideo.com/videoplayback?expire=5000&id=abc", now)
+	test.Validate(t, expiresAt.Unix(), int64(5000), "The expire query parameter should be used")
+
+	expiresAt = streamURLExpiresAt("https://example.com/song.mp3", now)
+	test.Validate(t, expiresAt, now.Add(defaultStreamURLLifetime), "The default lifetime should be used")
+}
+
+func TestStreamURLCache(t *testing.T) {
+
+	now := time.Unix(0, 0)
+	cache := newStreamURLCache(func() time.Time { return now })
+
+	calls := 0
+	resolve := func() (string, error) {
+		calls++
+		return fmt.Sprintf("https://example.com/stream?call=%d", calls), nil
+	}
+
+	url1, _ := cache.get("youtube:abc", resolve)
+	url2, _ := cache.get("youtube:abc", resolve)
+	test.Validate(t, calls, 1, "The second call should use the cache")
+	test.Validate(t, url1, url2, "The cached URL should be returned")
+
+	// Close enough to the expiry that it should be resolved again
+	now = now.Add(defaultStreamURLLifetime - streamURLExpiryMargin)
+	url3, _ := cache.get("youtube:abc", resolve)
+	test.Validate(t, calls, 2, "Expired URLs should be resolved again")
+	test.Validate(t, url3, "https://example.com/stream?call=2", "")
+
+	_, err := cache.get("youtube:broken", func() (string, error) { return "", errors.New("failed") })
+	if err == nil {
+		t.Error("The error from resolve should be returned")
+	}
+	test.Validate(t, len(cache.entries), 1, "Failed resolves should not be cached")
+
+	now = now.Add(defaultStreamURLLifetime)
+	cache.removeExpired()
+	test.Validate(t, len(cache.entries), 0, "removeExpired() should remove the expired URLs")
+}
@@ -0,0 +1,134 @@
+package music
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+)
+
+// SourceResolver turns the input from the play command into a playable song.
+// Each audio source (youtube, direct links, local files) has its own resolver
+type SourceResolver interface {
+	// Name is saved on the song so the correct resolver can be found when it is time to play it
+	Name() string
+	// Match returns true if the resolver can handle the input
+	Match(input string) bool
+	// Resolve fills in the metadata of the song, such as the title and duration
+	Resolve(input string, song *Song) error
+	// StreamURL returns the URL or file path ffmpeg will read the audio from
+	StreamURL(song *Song) (string, error)
+}
+
+// StreamOpener is implemented by the resolvers that download the audio themselves.
+// The audio is given to ffmpeg on stdin, so ffmpeg never connects to the link
+type StreamOpener interface {
+	OpenStream(song *Song) (io.ReadCloser, error)
+}
+
+// The order matters. The first resolver that matches the input will be used
+var resolvers []SourceResolver
+
+// initializeResolvers registers the resolvers that are enabled in the config
+func initializeResolvers() {
+
+	resolvers = []SourceResolver{}
+
+	youtubeEnabled := true
+	if err := utils.ValidateYoutubeAPIKey(); err != nil {
+		malm.Info("Youtube music disabled. %s", err.Error())
+		youtubeEnabled = false
+	}
+
+	if youtubeEnabled {
+		resolvers = append(resolvers, &youtubeResolver{})
+	}
+
+	if len(config.CONFIG.Music.MusicDirectory) > 0 {
+		if _, err := os.Stat(config.CONFIG.Music.MusicDirectory); err != nil {
+			malm.Warn("Could not find the music directory '%s'. Local files disabled", config.CONFIG.Music.MusicDirectory)
+		} else {
+			resolvers = append(resolvers, newLocalFileResolver(config.CONFIG.Music.MusicDirectory))
+		}
+	}
+
+	if config.CONFIG.Music.AllowDirectURLs {
+		resolvers = append(resolvers, newDirectURLResolver(nil))
+	}
+
+	// Searching youtube accepts any text, so it has to be tried last
+	if youtubeEnabled {
+		resolvers = append(resolvers, &youtubeSearchResolver{})
+	}
+}
+
+// findResolver returns the first resolver that can handle the input or nil
+func findResolver(input string) SourceResolver {
+	for _, r := range resolvers {
+		if r.Match(input) {
+			return r
+		}
+	}
+	return nil
+}
+
+// getResolverByName returns the resolver with the given name or nil
+func getResolverByName(name string) SourceResolver {
+	for _, r := range resolvers {
+		if r.Name() == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// probeDuration uses ffprobe to find the length of an audio file
+func probeDuration(filePath string) (time.Duration, error) {
+	return runFFprobe(filePath, nil)
+}
+
+// probeDurationFrom uses ffprobe to find the length of the audio read from the reader
+func probeDurationFrom(audio io.Reader) (time.Duration, error) {
+	return runFFprobe("pipe:0", audio)
+}
+
+func runFFprobe(input string, stdin io.Reader) (time.Duration, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "ffprobe", "-v", "error", "-show_entries", "format=duration", "-of", "default=noprint_wrappers=1:nokey=1", "-i", input)
+	cmd.Stdin = stdin
+
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, err
+	}
+
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(string(output)), 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// setProbedDuration sets the duration of the song. If the duration can't be found,
+// the song is only accepted when there is no length limit
+func setProbedDuration(song *Song, probe func() (time.Duration, error)) error {
+
+	duration, err := probe()
+	if err != nil && hasSongLengthLimit() {
+		return errUnknownDuration
+	}
+
+	song.Duration = duration
+	return nil
+}
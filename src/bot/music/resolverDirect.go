@@ -0,0 +1,171 @@
+package music
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// The file extensions that are accepted as audio files
+var audioExtensions = []string{".mp3", ".ogg", ".opus", ".wav", ".flac", ".m4a", ".aac", ".webm"}
+
+const errStatusDirectURL = "direct audio error - status code: %d - url: %s"
+
+var errBlockedAddress = errors.New("links to private or local addresses are not allowed")
+
+// Shared by internet providers, so it is not a public address either
+var carrierGradeNAT = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// directURLResolver handles links pointing directly to an audio file.
+// The audio is downloaded by the resolver and given to ffprobe and ffmpeg, so every connection is checked by the client
+type directURLResolver struct {
+	client *http.Client
+	probe  func(audio io.Reader) (time.Duration, error)
+}
+
+// newDirectURLResolver creates the resolver. If client is nil, a client that can't connect to private or local addresses is used
+func newDirectURLResolver(client *http.Client) *directURLResolver {
+	if client == nil {
+		client = newCheckedClient(blockPrivateAddresses)
+	}
+	return &directURLResolver{client: client, probe: probeDurationFrom}
+}
+
+// newCheckedClient creates a client that calls control before each connection, including the ones made for redirects.
+// There is no total timeout, since a song is streamed for minutes
+func newCheckedClient(control func(network, address string, c syscall.RawConn) error) *http.Client {
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second, Control: control}
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
+
+func (r *directURLResolver) Name() string {
+	return "direct"
+}
+
+func (r *directURLResolver) Match(input string) bool {
+
+	parsedURL, err := url.Parse(input)
+	if err != nil {
+		return false
+	}
+
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return false
+	}
+
+	return hasAudioExtension(parsedURL.Path)
+}
+
+func (r *directURLResolver) Resolve(input string, song *Song) error {
+
+	parsedURL, err := url.Parse(input)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	// Only the start of the file is downloaded, as ffprobe stops reading when it has found the length
+	res, err := r.get(ctx, input)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if err := setProbedDuration(song, func() (time.Duration, error) { return r.probe(res.Body) }); err != nil {
+		return err
+	}
+
+	song.Title = trimAudioExtension(path.Base(parsedURL.Path))
+	song.ChannelName = parsedURL.Host
+	song.URL = input
+	return nil
+}
+
+// The link is only used to cache the song. The audio is read from OpenStream
+func (r *directURLResolver) StreamURL(song *Song) (string, error) {
+	return song.URL, nil
+}
+
+// OpenStream downloads the audio. Remember to close it
+func (r *directURLResolver) OpenStream(song *Song) (io.ReadCloser, error) {
+
+	res, err := r.get(context.Background(), song.URL)
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+// get downloads the link. An error is returned if the status is not 200 OK
+func (r *directURLResolver) get(ctx context.Context, link string) (*http.Response, error) {
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf(errStatusDirectURL, res.StatusCode, link)
+	}
+	return res, nil
+}
+
+// blockPrivateAddresses stops the client from connecting to the network of the bot. It runs after the host name is looked up
+func blockPrivateAddresses(network, address string, _ syscall.RawConn) error {
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if isBlockedIP(net.ParseIP(host)) {
+		return errBlockedAddress
+	}
+	return nil
+}
+
+// isBlockedIP returns true if the address is not a public internet address
+func isBlockedIP(ip net.IP) bool {
+	return ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() || carrierGradeNAT.Contains(ip)
+}
+
+// hasAudioExtension returns true if the file name ends with one of the accepted audio extensions
+func hasAudioExtension(name string) bool {
+	name = strings.ToLower(name)
+	for _, extension := range audioExtensions {
+		if strings.HasSuffix(name, extension) {
+			return true
+		}
+	}
+	return false
+}
+
+// trimAudioExtension removes the audio extension from the file name. "song.mp3" -> "song"
+func trimAudioExtension(name string) string {
+	return strings.TrimSuffix(name, path.Ext(name))
+}
@@ -0,0 +1,85 @@
+package music
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var errOutsideMusicDirectory = errors.New("the file is not in the music directory")
+
+// localFileResolver handles audio files in the music directory set in the config
+type localFileResolver struct {
+	directory string
+	probe     func(filePath string) (time.Duration, error)
+}
+
+func newLocalFileResolver(directory string) *localFileResolver {
+	return &localFileResolver{directory: directory, probe: probeDuration}
+}
+
+func (r *localFileResolver) Name() string {
+	return "local"
+}
+
+func (r *localFileResolver) Match(input string) bool {
+
+	if !hasAudioExtension(input) {
+		return false
+	}
+
+	filePath, err := r.path(input)
+	if err != nil {
+		return false
+	}
+
+	info, err := os.Stat(filePath)
+	return err == nil && !info.IsDir()
+}
+
+func (r *localFileResolver) Resolve(input string, song *Song) error {
+
+	filePath, err := r.path(input)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(filePath); err != nil {
+		return err
+	}
+
+	if err := setProbedDuration(song, func() (time.Duration, error) { return r.probe(filePath) }); err != nil {
+		return err
+	}
+
+	song.Title = trimAudioExtension(filepath.Base(filePath))
+	song.ChannelName = "Local file"
+	song.StreamURL = filePath
+	return nil
+}
+
+// Local files never expire, so the path found when resolving is used
+func (r *localFileResolver) StreamURL(song *Song) (string, error) {
+	return song.StreamURL, nil
+}
+
+// path returns the full path to the file. The file has to be inside the music directory
+func (r *localFileResolver) path(input string) (string, error) {
+
+	directory, err := filepath.Abs(r.directory)
+	if err != nil {
+		return "", err
+	}
+
+	filePath := filepath.Join(directory, filepath.Clean(string(filepath.Separator)+input))
+
+	// Stops inputs such as "../../secret.mp3"
+	relative, err := filepath.Rel(directory, filePath)
+	if err != nil || strings.HasPrefix(relative, "..") {
+		return "", errOutsideMusicDirectory
+	}
+
+	return filePath, nil
+}
@@ -0,0 +1,81 @@
+package music
+
+import (
+	"net/url"
+	"regexp"
+)
+
+const (
+	youtubePattern string = `(youtube\.com\/watch\?v=)`
+	urlPattern     string = `[-a-zA-Z0-9@:%._\+~#=]{1,256}\.[a-zA-Z0-9()]{1,6}\b([-a-zA-Z0-9()@:%_\+.~#?&//=]*)`
+)
+
+var (
+	youtubeRegex = regexp.MustCompile(youtubePattern)
+	urlRegex     = regexp.MustCompile(urlPattern)
+)
+
+// youtubeResolver handles youtube links
+type youtubeResolver struct{}
+
+func (r *youtubeResolver) Name() string {
+	return "youtube"
+}
+
+func (r *youtubeResolver) Match(input string) bool {
+	return youtubeRegex.MatchString(input)
+}
+
+func (r *youtubeResolver) Resolve(input string, song *Song) error {
+
+	parsedURL, err := url.Parse(input)
+	if err != nil {
+		return err
+	}
+
+	videoID := parsedURL.Query().Get("v")
+
+	title, thumbnail, channelName, duration, err := youtubeFindByVideoID(videoID)
+	if err != nil {
+		return err
+	}
+
+	song.Title = title
+	song.Thumbnail = thumbnail
+	song.ChannelName = channelName
+	song.YoutubeVideoID = videoID
+	song.Duration = duration
+	return nil
+}
+
+// This function is slow. ~2 seconds
+func (r *youtubeResolver) StreamURL(song *Song) (string, error) {
+	if err := execYoutubeDL(song); err != nil {
+		return "", err
+	}
+	return song.StreamURL, nil
+}
+
+// youtubeSearchResolver searches youtube with the input. Used when the input is not a link
+type youtubeSearchResolver struct {
+	youtubeResolver
+}
+
+func (r *youtubeSearchResolver) Match(input string) bool {
+	return !urlRegex.MatchString(input)
+}
+
+func (r *youtubeSearchResolver) Resolve(input string, song *Song) error {
+
+	title, thumbnail, channelName, videoID, duration, err := youtubeSearch(input)
+	if err != nil {
+		return err
+	}
+
+	song.Title = title
+	song.Thumbnail = thumbnail
+	song.ChannelName = channelName
+	song.YoutubeVideoID = videoID
+	song.Duration = duration
+	return nil
+}
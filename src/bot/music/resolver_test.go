@@ -0,0 +1,177 @@
+package music
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+func TestLocalFileResolver(t *testing.T) {
+
+	directory := t.TempDir()
+	if err := os.WriteFile(filepath.Join(directory, "My Song.mp3"), []byte("audio"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(directory, "notes.txt"), []byte("text"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := newLocalFileResolver(directory)
+	r.probe = fakeProbe
+
+	test.Validate(t, r.Match("My Song.mp3"), true, "Existing audio file should match")
+	test.Validate(t, r.Match("Missing.mp3"), false, "Missing file should not match")
+	test.Validate(t, r.Match("notes.txt"), false, "Non audio file should not match")
+	test.Validate(t, r.Match("../My Song.mp3"), true, "The path is cleaned so it stays inside the directory")
+
+	var song Song
+	err := r.Resolve("My Song.mp3", &song)
+	test.Validate(t, err, nil, "Resolve() should not return an error")
+	test.Validate(t, song.Title, "My Song", "The title should be the file name without the extension")
+	test.Validate(t, song.Duration, 3*time.Minute, "The duration should be probed")
+
+	streamURL, err := r.StreamURL(&song)
+	test.Validate(t, err, nil, "StreamURL() should not return an error")
+	test.Validate(t, streamURL, filepath.Join(directory, "My Song.mp3"), "StreamURL() should be the full path to the file")
+}
+
+func TestDirectURLResolver(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/music/track.ogg" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "audio/ogg")
+		w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+
+	r := newDirectURLResolver(server.Client())
+	r.probe = fakeAudioProbe(t)
+
+	test.Validate(t, r.Match(server.URL+"/music/track.ogg"), true, "Link to an audio file should match")
+	test.Validate(t, r.Match(server.URL+"/page.html"), false, "Link to a web page should not match")
+	test.Validate(t, r.Match("track.ogg"), false, "Input without a scheme should not match")
+
+	var song Song
+	err := r.Resolve(server.URL+"/music/track.ogg", &song)
+	test.Validate(t, err, nil, "Resolve() should not return an error")
+	test.Validate(t, song.Title, "track", "The title should be the file name without the extension")
+	test.Validate(t, song.GetURL(), server.URL+"/music/track.ogg", "GetURL() should be the link")
+	test.Validate(t, song.Duration, 3*time.Minute, "The duration should be probed")
+
+	audio, err := r.OpenStream(&song)
+	test.Validate(t, err, nil, "OpenStream() should not return an error")
+	content, _ := io.ReadAll(audio)
+	audio.Close()
+	test.Validate(t, string(content), "audio", "OpenStream() should return the audio from the link")
+
+	if err := r.Resolve(server.URL+"/music/missing.ogg", &Song{}); err == nil {
+		t.Error("Resolve() should return an error when the file does not exist")
+	}
+}
+
+func TestDirectURLResolverBlocksPrivateAddresses(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	defer server.Close()
+
+	r := newDirectURLResolver(nil)
+	r.probe = fakeAudioProbe(t)
+
+	err := r.Resolve(server.URL+"/track.ogg", &Song{})
+	test.Validate(t, errors.Is(err, errBlockedAddress), true, "Links to the local machine should be blocked")
+}
+
+func TestDirectURLResolverBlocksRedirects(t *testing.T) {
+
+	internalRequests := 0
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		internalRequests++
+		w.Write([]byte("secret"))
+	}))
+	defer internal.Close()
+
+	public := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Redirect(w, req, internal.URL+"/track.ogg", http.StatusFound)
+	}))
+	defer public.Close()
+
+	// Both servers are on the local machine, so the internal one is blocked by its address instead
+	internalAddress := strings.TrimPrefix(internal.URL, "http://")
+	r := newDirectURLResolver(newCheckedClient(func(network, address string, _ syscall.RawConn) error {
+		if address == internalAddress {
+			return errBlockedAddress
+		}
+		return nil
+	}))
+	r.probe = fakeAudioProbe(t)
+
+	err := r.Resolve(public.URL+"/track.ogg", &Song{})
+	test.Validate(t, errors.Is(err, errBlockedAddress), true, "Resolve() should not follow a redirect to a blocked address")
+
+	_, err = r.OpenStream(&Song{URL: public.URL + "/track.ogg"})
+	test.Validate(t, errors.Is(err, errBlockedAddress), true, "OpenStream() should not follow a redirect to a blocked address")
+
+	test.Validate(t, internalRequests, 0, "The blocked address should never be reached")
+}
+
+func TestIsBlockedIP(t *testing.T) {
+
+	blocked := []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "fd00::1", "fe80::1"}
+	for _, address := range blocked {
+		test.Validate(t, isBlockedIP(net.ParseIP(address)), true, address+" should be blocked")
+	}
+
+	allowed := []string{"93.184.216.34", "2606:2800:220:1:248:1893:25c8:1946"}
+	for _, address := range allowed {
+		test.Validate(t, isBlockedIP(net.ParseIP(address)), false, address+" should be allowed")
+	}
+}
+
+func TestFindResolver(t *testing.T) {
+
+	directory := t.TempDir()
+	if err := os.WriteFile(filepath.Join(directory, "local.mp3"), []byte("audio"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resolvers = []SourceResolver{
+		&youtubeResolver{},
+		newLocalFileResolver(directory),
+		newDirectURLResolver(nil),
+		&youtubeSearchResolver{},
+	}
+	defer func() { resolvers = nil }()
+
+	test.Validate(t, findResolver("https://www.youtube.com/watch?v=5qap5aO4i9A").Name(), "youtube", "")
+	test.Validate(t, findResolver("local.mp3").Name(), "local", "")
+	test.Validate(t, findResolver("https://example.com/song.mp3").Name(), "direct", "")
+	test.Validate(t, findResolver("never gonna give you up").Name(), "youtube", "Text should search youtube")
+	test.Validate(t, findResolver("https://example.com/page.html"), nil, "Unsupported links should not have a resolver")
+}
+
+func fakeProbe(filePath string) (time.Duration, error) {
+	return 3 * time.Minute, nil
+}
+
+// fakeAudioProbe checks that the probe is given the downloaded audio
+func fakeAudioProbe(t *testing.T) func(audio io.Reader) (time.Duration, error) {
+	return func(audio io.Reader) (time.Duration, error) {
+		content, err := io.ReadAll(audio)
+		test.Validate(t, err, nil, "The audio should be readable")
+		test.Validate(t, string(content), "audio", "The probe should be given the audio from the link")
+		return 3 * time.Minute, nil
+	}
+}
@@ -0,0 +1,278 @@
+package music
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/context"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+// The queues are saved to the database so the music can be resumed after the bot has been restarted
+
+var (
+	sessionsFrozen  bool // Set when shutting down so disconnecting does not remove the saved sessions
+	sessionMutex    sync.Mutex
+	offerResumeOnce sync.Once
+)
+
+// saveSession saves the queue and playback state of the instance.
+// Removes the saved session if there is nothing left to play
+func (vi *VoiceInstance) saveSession() {
+
+	if !config.CONFIG.Music.ResumeSessions || vi.voice == nil {
+		return
+	}
+
+	sessionMutex.Lock()
+	defer sessionMutex.Unlock()
+
+	if sessionsFrozen {
+		return
+	}
+
+	if vi.QueueIsEmpty() || vi.isEndOfQueue() {
+		deleteSessionLocked(vi.GetGuildID())
+		return
+	}
+
+	session := vi.toMusicSession()
+	session.Save()
+}
+
+// toMusicSession converts the instance to the format saved in the database
+func (vi *VoiceInstance) toMusicSession() database.MusicSession {
+	position := vi.GetPlaybackPosition()
+
+	vi.queueMutex.Lock()
+	defer vi.queueMutex.Unlock()
+
+	session := database.MusicSession{
+		GuildID:        vi.GetGuildID(),
+		VoiceChannelID: vi.voice.ChannelID,
+		TextChannelID:  vi.GetChannelID(),
+		MessageID:      vi.GetMessageID(),
+		QueueIndex:     vi.queueIndex,
+		Looping:        vi.IsLooping(),
+		Position:       position,
+	}
+
+	for i, song := range vi.queue {
+		session.Songs = append(session.Songs, songToSessionSong(i, song))
+	}
+
+	return session
+}
+
+func songToSessionSong(position int, song Song) database.MusicSessionSong {
+
+	sessionSong := database.MusicSessionSong{
+		QueuePosition:  position,
+		Source:         song.Source,
+		YoutubeVideoID: song.YoutubeVideoID,
+		URL:            song.URL,
+		Title:          song.Title,
+		ChannelName:    song.ChannelName,
+		Thumbnail:      song.Thumbnail,
+		Duration:       song.Duration,
+		ChannelID:      song.ChannelID,
+		User:           song.User,
+	}
+
+	// The file path is needed to play local files again
+	if song.Source == "local" {
+		sessionSong.StreamURL = song.StreamURL
+	}
+
+	return sessionSong
+}
+
+func sessionSongToSong(sessionSong database.MusicSessionSong) Song {
+	return Song{
+		ChannelID:      sessionSong.ChannelID,
+		User:           sessionSong.User,
+		Thumbnail:      sessionSong.Thumbnail,
+		ChannelName:    sessionSong.ChannelName,
+		Title:          sessionSong.Title,
+		YoutubeVideoID: sessionSong.YoutubeVideoID,
+		StreamURL:      sessionSong.StreamURL,
+		URL:            sessionSong.URL,
+		Source:         sessionSong.Source,
+		Duration:       sessionSong.Duration,
+	}
+}
+
+// deleteSession removes the saved session for the guild
+func deleteSession(guildID string) {
+	sessionMutex.Lock()
+	defer sessionMutex.Unlock()
+
+	if sessionsFrozen {
+		return
+	}
+	deleteSessionLocked(guildID)
+}
+
+func deleteSessionLocked(guildID string) {
+	var session database.MusicSession
+	if session.QueryByGuildID(guildID) {
+		session.DeleteFromDB()
+	}
+}
+
+// SaveSessions saves the state of every instance. Called when the bot is shutting down.
+// No changes are saved after this has been called
+func SaveSessions() {
+
+	musicMutex.Lock()
+	active := make([]*VoiceInstance, 0, len(instances))
+	for _, vi := range instances {
+		active = append(active, vi)
+	}
+	musicMutex.Unlock()
+
+	for _, vi := range active {
+		vi.saveSession()
+	}
+
+	sessionMutex.Lock()
+	sessionsFrozen = true
+	sessionMutex.Unlock()
+
+	if len(active) > 0 {
+		malm.Info("Saved %d music sessions", len(active))
+	}
+}
+
+// OfferResumeSessions turns the overview messages of the saved sessions into a message with a resume button.
+// Only runs once, even if the bot reconnects
+func OfferResumeSessions(s *discordgo.Session) {
+
+	if !isMusicEnabled() || !config.CONFIG.Music.ResumeSessions {
+		return
+	}
+
+	offerResumeOnce.Do(func() {
+		for _, saved := range database.GetAllMusicSessions() {
+			var session database.MusicSession
+			if session.QueryByGuildID(saved.GuildID) {
+				offerResume(s, &session)
+			}
+		}
+	})
+}
+
+func offerResume(s *discordgo.Session, session *database.MusicSession) {
+
+	embeds := []*discordgo.MessageEmbed{
+		{
+			Title:       "The music was interrupted",
+			Description: fmt.Sprintf("The bot was restarted while playing music in <#%s>\n%d songs are left in the queue. Join a voice channel and press resume or use the ``resume`` command to continue listening", session.VoiceChannelID, len(session.Songs)-session.QueueIndex),
+			Color:       config.CONFIG.Colors.Neutral,
+		},
+	}
+	components := []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Resume",
+					CustomID: "resumeMusic",
+					Style:    3, // Green
+				},
+			},
+		},
+	}
+
+	// Reuse the old overview message if it is still there
+	if len(session.MessageID) > 0 {
+		_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			Channel:    session.TextChannelID,
+			ID:         session.MessageID,
+			Embeds:     embeds,
+			Components: components,
+		})
+		if err == nil {
+			return
+		}
+	}
+
+	msg, err := s.ChannelMessageSendComplex(session.TextChannelID, &discordgo.MessageSend{
+		Embeds:     embeds,
+		Components: components,
+	})
+	if err != nil {
+		malm.Error("Could not offer to resume the music in guild '%s': %s", session.GuildID, err)
+		return
+	}
+
+	session.MessageID = msg.ID
+	session.Save()
+}
+
+// resumeSession rejoins the voice channel of the user and continues the saved queue from where it was.
+// Returns the response for the user and true if it went well
+func resumeSession(guildID string, author *discordgo.User, channelID string) (string, bool) {
+
+	if instances[guildID] != nil {
+		return "The bot is already playing music. Use ``play`` to unpause", false
+	}
+
+	var session database.MusicSession
+	if !session.QueryByGuildID(guildID) {
+		return "There is no music to resume", false
+	}
+
+	vi, errStr := joinVoice(nil, author.ID, channelID)
+	if vi == nil {
+		return errStr, false
+	}
+
+	// Songs from sources that have been turned off since are skipped
+	queueIndex := session.QueueIndex
+	for i, sessionSong := range session.Songs {
+		if getResolverByName(sessionSong.Source) == nil {
+			if i < session.QueueIndex {
+				queueIndex--
+			}
+			continue
+		}
+		vi.AddToQueue(sessionSongToSong(sessionSong))
+	}
+
+	if queueIndex < 0 {
+		queueIndex = 0
+	}
+	vi.queueIndex = queueIndex
+	vi.SetLooping(session.Looping)
+	vi.seekTo = session.Position
+
+	if vi.QueueIsEmpty() || vi.isEndOfQueue() {
+		leaveVoice(vi)
+		return "None of the songs in the saved queue can be played anymore", false
+	}
+
+	// The resume offer is replaced by a new overview message
+	if len(session.MessageID) > 0 {
+		context.SESSION.ChannelMessageDelete(session.TextChannelID, session.MessageID)
+	}
+
+	vi.loading = true
+	complexMessage := &discordgo.MessageSend{}
+	CreateMusicOverviewMessage(channelID, complexMessage)
+
+	msg, err := context.SESSION.ChannelMessageSendComplex(channelID, complexMessage)
+	if err != nil {
+		malm.Error("Could not send message! %s", err)
+		leaveVoice(vi)
+		return "Something went wrong when resuming the music", false
+	}
+	vi.SetMessageID(msg.ID)
+	vi.SetChannelID(msg.ChannelID)
+
+	songSignal <- vi
+
+	return fmt.Sprintf("%s resumed the music", author.Username), true
+}
@@ -0,0 +1,33 @@
+package music
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+func TestSessionSongConversion(t *testing.T) {
+
+	song := Song{
+		ChannelID:      "channel",
+		User:           "user",
+		Title:          "song 1",
+		YoutubeVideoID: "5qap5aO4i9A",
+		StreamURL:      "https://expiring.link",
+		Source:         "youtube",
+		Duration:       3 * time.Minute,
+	}
+
+	sessionSong := songToSessionSong(2, song)
+	test.Validate(t, sessionSong.QueuePosition, 2, "The queue position should be saved")
+	test.Validate(t, sessionSong.StreamURL, "", "Youtube stream URLs expire and should not be saved")
+
+	restored := sessionSongToSong(sessionSong)
+	song.StreamURL = ""
+	test.Validate(t, restored, song, "The song should be the same after being restored")
+
+	local := Song{Title: "song 2", StreamURL: "/music/song 2.mp3", Source: "local"}
+	restored = sessionSongToSong(songToSessionSong(0, local))
+	test.Validate(t, restored.StreamURL, local.StreamURL, "The file path of local songs should be saved")
+}
@@ -1,6 +1,9 @@
 package music
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type Song struct {
 	ChannelID      string
@@ -10,14 +13,35 @@ type Song struct {
 	Title          string
 	YoutubeVideoID string
 	StreamURL      string
-	duration       string
+	URL            string // Link to the song for sources other than youtube
+	Source         string // Name of the resolver that found the song
+	Duration       time.Duration
 }
 
 /* SONG */
 
-// GetDuration returns the duration of the song
+// GetDuration returns the duration of the song as a pretty string
 func (s *Song) GetDuration() string {
-	return s.duration
+	return formatDuration(s.Duration)
+}
+
+// GetURL returns a link to the song. Empty for local files
+func (s *Song) GetURL() string {
+	if len(s.YoutubeVideoID) > 0 {
+		return s.GetYoutubeURL()
+	}
+	return s.URL
+}
+
+// cacheKey uniquely identifies the audio of the song
+func (s *Song) cacheKey() string {
+	if len(s.YoutubeVideoID) > 0 {
+		return s.Source + ":" + s.YoutubeVideoID
+	}
+	if len(s.URL) > 0 {
+		return s.Source + ":" + s.URL
+	}
+	return s.Source + ":" + s.StreamURL
 }
 
 // GetYoutubeURL returns the full youtube url of the song
@@ -2,13 +2,10 @@ package music
 
 import (
 	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
-	"net/url"
-	"regexp"
 	"strings"
+	"time"
 
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/context"
 	"github.com/CarlFlo/DiscordMoneyBot/src/config"
@@ -18,127 +15,14 @@ import (
 )
 
 var (
-	errDetectedNonYTURL      = errors.New("non youtube URL detected")
-	errEmptyYTResult         = errors.New("empty youtube search result")
-	errStatusYTSearchQuery   = "youtube search error - status code: %d - query: %s"
-	errStatusYTSearchVideoID = "youtube search error - status code: %d - videoID: %s"
+	errSongTooLong       = errors.New("the song is longer than the maximum allowed length")
+	errUnsupportedSource = errors.New("no source resolver can handle the input")
+	errUnknownSource     = errors.New("no source resolver with that name")
+	errUnknownDuration   = errors.New("the length of the song could not be found")
 )
 
-const (
-	youtubeFindEndpoint     string = "https://www.googleapis.com/youtube/v3/videos?part=snippet,contentDetails&key=%s&id=%s"
-	youtubeSearchEndpoint   string = "https://www.googleapis.com/youtube/v3/search?part=snippet&type=video&key=%s&q=%s&fields=items(id)"
-	youtubePlaylistEndpoint string = "https://www.googleapis.com/youtube/v3/playlistItems?part=snippet,contentDetails&key=%s&playlistId=%s&maxResults=50&fields=items(snippet)"
-)
-
-type youtubeResponseFind struct {
-	Items []itemsFind
-}
-
-type itemsFind struct {
-	Snippet        snippet
-	ID             string
-	ContentDetails contentDetails
-}
-
-type youtubeResponseSearch struct {
-	Items []itemsSearch
-}
-
-type itemsSearch struct {
-	ID id
-}
-
-type id struct {
-	VideoId string
-}
-
-type snippet struct {
-	Title        string
-	Thumbnails   thumbnails
-	ChannelTitle string
-}
-
-type thumbnails struct {
-	Standard standard
-}
-
-type standard struct {
-	Url    string
-	Width  int
-	Height int
-}
-
-type contentDetails struct {
-	Duration string
-}
-
-// Returns the title, thumbnail and channel of a youtube video
-// error if there was any problem
-func youtubeFindByVideoID(videoID string) (string, string, string, string, error) {
-
-	res, err := http.Get(fmt.Sprintf(youtubeFindEndpoint, config.CONFIG.Music.YoutubeAPIKey, videoID))
-	if err != nil {
-		return "", "", "", "", err
-	} else if res.StatusCode != 200 {
-		return "", "", "", "", fmt.Errorf(errStatusYTSearchVideoID, res.StatusCode, videoID)
-	}
-	defer res.Body.Close()
-
-	var page youtubeResponseFind
-
-	err = json.NewDecoder(res.Body).Decode(&page)
-	if err != nil {
-		return "", "", "", "", err
-	}
-
-	if len(page.Items) == 0 {
-		return "", "", "", "", errEmptyYTResult
-	}
-
-	title := page.Items[0].Snippet.Title
-	thumbnail := page.Items[0].Snippet.Thumbnails.Standard.Url
-	channelName := page.Items[0].Snippet.ChannelTitle
-	duration := formatYoutubeDuration(page.Items[0].ContentDetails.Duration)
-
-	// TODO: if duration is too long (set in config) return error
-
-	return title, thumbnail, channelName, duration, nil
-}
-
-func youtubeSearch(query string) (string, string, string, string, string, error) {
-
-	query = url.QueryEscape(query)
-	res, err := http.Get(fmt.Sprintf(youtubeSearchEndpoint, config.CONFIG.Music.YoutubeAPIKey, query))
-	if err != nil {
-		return "", "", "", "", "", err
-	} else if res.StatusCode != 200 {
-		return "", "", "", "", "", fmt.Errorf(errStatusYTSearchQuery, res.StatusCode, query)
-	}
-	defer res.Body.Close()
-
-	var page youtubeResponseSearch
-
-	err = json.NewDecoder(res.Body).Decode(&page)
-	if err != nil {
-		return "", "", "", "", "", err
-	}
-
-	if len(page.Items) == 0 {
-		return "", "", "", "", "", errEmptyYTResult
-	}
-
-	videoID := page.Items[0].ID.VideoId
-
-	title, thumbnail, channelName, duration, err := youtubeFindByVideoID(videoID)
-	if err != nil {
-		return "", "", "", "", "", err
-	}
-
-	return title, thumbnail, channelName, videoID, duration, nil
-}
-
 func isMusicEnabled() bool {
-	return youtubeAPIKeyPresent
+	return len(resolvers) > 0
 }
 
 func joinVoice(vi *VoiceInstance, authorID, channelID string) (*VoiceInstance, string) {
@@ -187,95 +71,91 @@ func joinVoice(vi *VoiceInstance, authorID, channelID string) (*VoiceInstance, s
 	return vi, ""
 }
 
-func leaveVoice(vi *VoiceInstance, m *discordgo.MessageCreate) {
-
-	vi.Disconnect()
+// leaveVoice disconnects and removes the instance. Only the first call does anything,
+// so the idle timer, a user and a disconnect can't leave at the same time. Returns false if the bot already had left
+func leaveVoice(vi *VoiceInstance) bool {
 
 	musicMutex.Lock()
-	delete(instances, vi.GetGuildID())
+	if vi.left {
+		musicMutex.Unlock()
+		return false
+	}
+	vi.left = true
+	// A new instance could have been created for the guild
+	if instances[vi.GetGuildID()] == vi {
+		delete(instances, vi.GetGuildID())
+	}
 	musicMutex.Unlock()
-}
-
-func parseMusicInput(m *discordgo.MessageCreate, input string, song *Song) error {
-
-	var title, thumbnail, channelName, videoID, duration string
-	var err error
 
-	ytRegex := regexp.MustCompile(youtubePattern)
-	urlRegex := regexp.MustCompile(urlPattern)
-
-	if ytRegex.MatchString(input) {
-		// Youtube link
+	vi.Disconnect()
 
-		parsedURL, err := url.Parse(input)
-		if err != nil {
-			return err
-		}
+	// There is nothing to resume when the bot has left on purpose
+	deleteSession(vi.GetGuildID())
+	return true
+}
 
-		query := parsedURL.Query()
-		videoID = query.Get("v")
+func parseMusicInput(m *discordgo.MessageCreate, input string, song *Song) error {
 
-		title, thumbnail, channelName, duration, err = youtubeFindByVideoID(videoID)
-		if err != nil {
-			return err
-		}
+	resolver := findResolver(input)
+	if resolver == nil {
+		return errUnsupportedSource
+	}
 
-	} else if urlRegex.MatchString(input) {
-		// URL from another source than Youtube
-		parsedURL, err := url.Parse(input)
-		if err != nil {
-			return err
-		}
+	if err := resolver.Resolve(input, song); err != nil {
+		return err
+	}
 
-		malm.Debug("%s", parsedURL.Host)
-		return errDetectedNonYTURL
-	} else {
-		// Presumably a song name. Search Youtube
-		title, thumbnail, channelName, videoID, duration, err = youtubeSearch(input)
-		if err != nil {
-			return err
-		}
+	if isSongTooLong(song.Duration) {
+		return errSongTooLong
 	}
 
 	// Update the song object
+	song.Source = resolver.Name()
 	song.ChannelID = m.ChannelID
 	song.User = m.Author.ID
-	song.Title = title
-	song.Thumbnail = thumbnail
-	song.ChannelName = channelName
-	song.YoutubeVideoID = videoID
-	song.duration = duration
 
 	return nil
 }
 
-// formatYoutubeDuration formats the youtube duration string
-// PT1H24M47S -> 1 hour 24 minutes and 47 seconds
-func formatYoutubeDuration(input string) string {
-	// example string: PT1H24M47S
+// isSongTooLong returns true if the duration is over the limit set in the config
+// A limit of 0 means that there is no limit
+func isSongTooLong(duration time.Duration) bool {
+	maxLength := time.Duration(config.CONFIG.Music.MaxSongLengthMinutes) * time.Minute
+	return hasSongLengthLimit() && duration > maxLength
+}
+
+func hasSongLengthLimit() bool {
+	return config.CONFIG.Music.MaxSongLengthMinutes > 0
+}
+
+// formatDuration formats a duration to a pretty string
+// 25h24m47s -> 1d 1h 24m 47s
+func formatDuration(duration time.Duration) string {
 
 	var buffer bytes.Buffer
 
-	// Removes the prefix
-	input = strings.TrimPrefix(input, "PT")
+	duration = duration.Round(time.Second)
 
-	// Split the string into hours, minutes and seconds
-	split := strings.Split(input, "H")
-	if len(split) != 1 {
-		buffer.WriteString(split[0] + "h ")
-		input = split[1]
-	}
+	days := duration / (time.Hour * 24)
+	duration -= days * time.Hour * 24
+	hours := duration / time.Hour
+	duration -= hours * time.Hour
+	minutes := duration / time.Minute
+	duration -= minutes * time.Minute
+	seconds := duration / time.Second
 
-	split = strings.Split(input, "M")
-	if len(split) != 1 {
-		buffer.WriteString(split[0] + "m ")
-		input = split[1]
+	if days > 0 {
+		buffer.WriteString(fmt.Sprintf("%dd ", days))
 	}
-
-	split = strings.Split(input, "S")
-	if len(split) != 1 {
-		buffer.WriteString(split[0] + "s")
+	if hours > 0 {
+		buffer.WriteString(fmt.Sprintf("%dh ", hours))
+	}
+	if minutes > 0 {
+		buffer.WriteString(fmt.Sprintf("%dm ", minutes))
+	}
+	if seconds > 0 {
+		buffer.WriteString(fmt.Sprintf("%ds", seconds))
 	}
 
-	return buffer.String()
+	return strings.TrimSpace(buffer.String())
 }
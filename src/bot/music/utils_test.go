@@ -2,22 +2,57 @@ package music
 
 import (
 	"testing"
+	"time"
 
 	"github.com/CarlFlo/DiscordMoneyBot/src/test"
 )
 
-func TestFormatYoutubeDuration(t *testing.T) {
+func TestFormatDuration(t *testing.T) {
 
-	r1 := formatYoutubeDuration("PT1H24M47S")
+	r1 := formatDuration(time.Hour + 24*time.Minute + 47*time.Second)
 	test.Validate(t, r1, "1h 24m 47s", "")
 
-	r2 := formatYoutubeDuration("PT12M12S")
+	r2 := formatDuration(12*time.Minute + 12*time.Second)
 	test.Validate(t, r2, "12m 12s", "")
 
-	r3 := formatYoutubeDuration("PT60S")
-	test.Validate(t, r3, "60s", "")
+	r3 := formatDuration(60 * time.Second)
+	test.Validate(t, r3, "1m", "")
 
-	r4 := formatYoutubeDuration("PT")
+	r4 := formatDuration(0)
 	test.Validate(t, r4, "", "")
 
+	r5 := formatDuration(26*time.Hour + 5*time.Second)
+	test.Validate(t, r5, "1d 2h 5s", "")
+}
+
+func TestParseYoutubeDuration(t *testing.T) {
+
+	r1, err := parseYoutubeDuration("PT1H24M47S")
+	test.Validate(t, err, nil, "")
+	test.Validate(t, r1, time.Hour+24*time.Minute+47*time.Second, "")
+
+	r2, _ := parseYoutubeDuration("PT60S")
+	test.Validate(t, r2, time.Minute, "")
+
+	r3, _ := parseYoutubeDuration("P1DT2H")
+	test.Validate(t, r3, 26*time.Hour, "Days should be included")
+
+	r4, err := parseYoutubeDuration("P0D")
+	test.Validate(t, err, nil, "Live streams have the duration P0D")
+	test.Validate(t, r4, time.Duration(0), "")
+
+	r5, _ := parseYoutubeDuration("PT")
+	test.Validate(t, r5, time.Duration(0), "")
+
+	if _, err := parseYoutubeDuration("1H24M"); err == nil {
+		t.Error("Missing 'P' prefix should return an error")
+	}
+
+	if _, err := parseYoutubeDuration("PT12"); err == nil {
+		t.Error("A number without a unit should return an error")
+	}
+
+	if _, err := parseYoutubeDuration("P5H"); err == nil {
+		t.Error("Hours before the 'T' should return an error")
+	}
 }
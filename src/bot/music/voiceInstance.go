@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/CarlFlo/DiscordMoneyBot/src/bot/context"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
 	"github.com/CarlFlo/malm"
 	"github.com/bwmarrin/discordgo"
 	"github.com/jung-m/dca"
@@ -28,21 +29,45 @@ type VoiceInstance struct {
 	done       chan error // Used to interrupt the stream
 	messageID  string
 	channelID  string
+	idleMutex  sync.Mutex
+	idleTimer  *time.Timer // Leaves the voice channel when it fires
+	skipVotes  skipVotes
+	left       bool // Set once the bot has left the voice channel. Guarded by musicMutex
 	DJ
+	AudioSettings
 }
 
 // The variables keeping track of the playback state
 type DJ struct {
-	playing    bool
-	paused     bool
-	loading    bool
-	stop       bool
-	looping    bool
-	queueIndex int
+	playing     bool
+	paused      bool
+	loading     bool
+	stop        bool
+	looping     bool
+	restart     bool          // Restarts the current song instead of going to the next
+	seekTo      time.Duration // Where the next stream of the current song will start
+	startOffset time.Duration // Where in the song the current stream started
+	queueIndex  int
+}
+
+// The settings used by the encoder
+type AudioSettings struct {
+	volume  int    // In percent. 100 is normal
+	bitrate int    // In kb/s
+	filter  string // Name of the filter preset. Empty for no filter
 }
 
 func (vi *VoiceInstance) New(guildID string) error {
 	vi.guildID = guildID
+	vi.volume = config.CONFIG.Music.DefaultVolume
+	vi.bitrate = config.CONFIG.Music.DefaultBitrate
+
+	if vi.volume <= 0 {
+		vi.volume = defaultVolume
+	}
+	if vi.bitrate <= 0 {
+		vi.bitrate = defaultBitrate
+	}
 	return nil
 }
 
@@ -66,6 +91,10 @@ func (vi *VoiceInstance) PlayQueue() {
 
 	defer vi.playingStopped()
 
+	// Nothing is playing when the function returns
+	defer vi.startIdleTimer()
+	vi.stopIdleTimer()
+
 	for {
 		vi.playingStarted()
 
@@ -81,8 +110,19 @@ func (vi *VoiceInstance) PlayQueue() {
 			return
 		}
 
+		// The settings changed, so the same song is played again from where it was
+		if vi.restart && !vi.stop {
+			vi.restart = false
+			continue
+		}
+		vi.restart = false
+		vi.seekTo = 0
+		vi.startOffset = 0
+		vi.resetSkipVotes()
+
 		if vi.stop {
 			vi.ClearQueue()
+			deleteSession(vi.GetGuildID())
 			return
 		}
 		vi.FinishedPlayingSong()
@@ -95,7 +135,8 @@ func (vi *VoiceInstance) PlayQueue() {
 			return
 		}
 
-		if vi.QueueIsEmpty() {
+		if vi.QueueIsEmpty() || vi.isEndOfQueue() {
+			vi.saveSession()
 			return
 		}
 	}
@@ -103,31 +144,31 @@ func (vi *VoiceInstance) PlayQueue() {
 
 func (vi *VoiceInstance) StreamAudio() error {
 
-	settings := dca.StdEncodeOptions
-	// Custom settings
-	settings.RawOutput = true
-	settings.Bitrate = 64
-	//settings.Application = "lowdelay"
-
 	song, err := vi.GetFirstInQueue()
 	if err != nil {
 		return err
 	}
 
-	// This function is slow. ~2 seconds
-	err = execYoutubeDL(&song)
-	if err != nil {
-		return err
-	}
+	vi.startOffset = vi.seekTo
+	vi.seekTo = 0
+	settings := vi.encodeOptions()
 
-	vi.encoder, err = dca.EncodeFile(song.StreamURL, settings)
+	var audio io.ReadCloser
+	vi.encoder, audio, err = encodeSong(&song, settings)
 	if err != nil {
 		return err
 	}
+	if audio != nil {
+		defer audio.Close()
+	}
 
 	vi.done = make(chan error)
 	vi.stream = dca.NewStream(vi.encoder, vi.voice, vi.done)
 
+	streamURLs.removeExpired()
+	vi.prefetchNextSong()
+	vi.saveSession()
+
 	// Update the message to reflect that the song is playing
 
 	vi.loading = false
@@ -155,6 +196,35 @@ func (vi *VoiceInstance) StreamAudio() error {
 	}
 }
 
+// encodeSong starts encoding the song. If the resolver downloads the audio itself, it is given to ffmpeg on stdin
+// and returned, so it can be closed when the song has ended
+func encodeSong(song *Song, settings *dca.EncodeOptions) (*dca.EncodeSession, io.ReadCloser, error) {
+
+	if opener, ok := getResolverByName(song.Source).(StreamOpener); ok {
+
+		audio, err := opener.OpenStream(song)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		encoder, err := dca.EncodeMem(audio, settings)
+		if err != nil {
+			audio.Close()
+			return nil, nil, err
+		}
+		return encoder, audio, nil
+	}
+
+	// Instant if the song was prefetched while the previous song was playing
+	streamURL, err := resolveStreamURL(song)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encoder, err := dca.EncodeFile(streamURL, settings)
+	return encoder, nil, err
+}
+
 // #### Queue Code ####
 
 func (vi *VoiceInstance) GetFirstInQueue() (Song, error) {
@@ -182,6 +252,30 @@ func (vi *VoiceInstance) ClearQueue() {
 	vi.queue = vi.queue[:vi.queueIndex+1]
 }
 
+// RemoveSongsByUser removes the songs after the current song that were requested by the user.
+// Returns the number of removed songs
+func (vi *VoiceInstance) RemoveSongsByUser(userID string) int {
+	vi.queueMutex.Lock()
+	defer vi.queueMutex.Unlock()
+
+	if vi.queueIndex+1 >= len(vi.queue) {
+		return 0
+	}
+
+	kept := vi.queue[:vi.queueIndex+1]
+	removed := 0
+	for _, song := range vi.queue[vi.queueIndex+1:] {
+		if song.User == userID {
+			removed++
+			continue
+		}
+		kept = append(kept, song)
+	}
+	vi.queue = kept
+
+	return removed
+}
+
 // Removes all songs in the queue before the current song.
 func (vi *VoiceInstance) ClearQueuePrev() {
 	vi.queueMutex.Lock()
@@ -212,6 +306,41 @@ func (vi *VoiceInstance) GetSongByIndex(i int) Song {
 	return vi.queue[i]
 }
 
+// GetQueueDuration returns how long it will take to play the rest of the queue.
+// Includes what is left of the current song
+func (vi *VoiceInstance) GetQueueDuration() time.Duration {
+	return vi.TimeUntilSong(vi.GetQueueLength())
+}
+
+// TimeUntilSong estimates how long it will take until the song with the given index starts playing
+func (vi *VoiceInstance) TimeUntilSong(i int) time.Duration {
+	vi.queueMutex.Lock()
+	defer vi.queueMutex.Unlock()
+
+	var duration time.Duration
+	for j := vi.queueIndex; j < i && j < len(vi.queue); j++ {
+		duration += vi.queue[j].Duration
+	}
+
+	// The current song has already been playing for a while
+	if i > vi.queueIndex {
+		duration -= vi.GetPlaybackPosition()
+	}
+
+	if duration < 0 {
+		return 0
+	}
+	return duration
+}
+
+// GetPlaybackPosition returns how far into the current song the stream is
+func (vi *VoiceInstance) GetPlaybackPosition() time.Duration {
+	if vi.stream == nil || vi.loading {
+		return vi.startOffset
+	}
+	return vi.startOffset + vi.stream.PlaybackPosition()
+}
+
 //////////////////////////// Queue code end ////////////////////////////
 
 func (vi *VoiceInstance) FinishedPlayingSong() {
@@ -249,6 +378,7 @@ func (vi *VoiceInstance) isEndOfQueue() bool {
 
 // Disconnect dissconnects the bot from the voice connection
 func (vi *VoiceInstance) Disconnect() {
+	vi.stopIdleTimer()
 	vi.Stop()
 	time.Sleep(200 * time.Millisecond)
 
@@ -319,6 +449,23 @@ func (vi *VoiceInstance) Stop() bool {
 	return true
 }
 
+// restartStream restarts the current song from the given position so new audio settings are used.
+// Returns false if nothing is playing
+func (vi *VoiceInstance) restartStream(position time.Duration) bool {
+
+	if !vi.playing || vi.loading {
+		return false
+	}
+
+	vi.seekTo = position
+	vi.restart = true
+
+	// This will interupt and stop the stream
+	vi.done <- nil
+
+	return true
+}
+
 // Toggles between play and pause
 func (vi *VoiceInstance) PauseToggle() {
 
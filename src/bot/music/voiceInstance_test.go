@@ -2,6 +2,7 @@ package music
 
 import (
 	"testing"
+	"time"
 
 	"github.com/CarlFlo/DiscordMoneyBot/src/test"
 )
@@ -87,3 +88,38 @@ func TestQueueIncrementDecrement(t *testing.T) {
 	vi.DecrementQueueIndex()
 	test.Validate(t, vi.GetQueueIndex(), 0, "QueueIndex() should be 0")
 }
+
+func TestQueueDuration(t *testing.T) {
+	vi := VoiceInstance{}
+	test.Validate(t, vi.GetQueueDuration(), time.Duration(0), "An empty queue should have no duration")
+
+	vi.AddToQueue(Song{Title: "song 1", Duration: 3 * time.Minute})
+	vi.AddToQueue(Song{Title: "song 2", Duration: 4 * time.Minute})
+	vi.AddToQueue(Song{Title: "song 3", Duration: 5 * time.Minute})
+	test.Validate(t, vi.GetQueueDuration(), 12*time.Minute, "GetQueueDuration() should be the sum of all songs")
+
+	test.Validate(t, vi.TimeUntilSong(0), time.Duration(0), "The current song is playing now")
+	test.Validate(t, vi.TimeUntilSong(2), 7*time.Minute, "Song 3 plays after song 1 and 2")
+
+	vi.FinishedPlayingSong()
+	test.Validate(t, vi.GetQueueDuration(), 9*time.Minute, "Played songs should not be included")
+	test.Validate(t, vi.TimeUntilSong(2), 4*time.Minute, "Song 3 plays after song 2")
+}
+
+func TestRemoveSongsByUser(t *testing.T) {
+	vi := VoiceInstance{}
+	vi.AddToQueue(Song{Title: "song 1", User: "a"})
+	vi.AddToQueue(Song{Title: "song 2", User: "b"})
+	vi.AddToQueue(Song{Title: "song 3", User: "a"})
+	vi.AddToQueue(Song{Title: "song 4", User: "b"})
+
+	// The current song is not removed
+	removed := vi.RemoveSongsByUser("a")
+	test.Validate(t, removed, 1, "RemoveSongsByUser() should remove 1 song")
+	test.Validate(t, vi.GetQueueLength(), 3, "QueueLength() should be 3 after RemoveSongsByUser()")
+	test.Validate(t, vi.GetSongByIndex(0).Title, "song 1", "The current song should not be removed")
+	test.Validate(t, vi.GetSongByIndex(2).Title, "song 4", "The order of the other songs should be kept")
+
+	removed = vi.RemoveSongsByUser("c")
+	test.Validate(t, removed, 0, "RemoveSongsByUser() should not remove songs from other users")
+}
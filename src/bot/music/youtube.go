@@ -3,9 +3,230 @@ package music
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
 	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+)
+
+var (
+	errEmptyYTResult         = errors.New("empty youtube search result")
+	errInvalidYTDuration     = "invalid youtube duration: '%s'"
+	errStatusYTSearchQuery   = "youtube search error - status code: %d - query: %s"
+	errStatusYTSearchVideoID = "youtube search error - status code: %d - videoID: %s"
+)
+
+const (
+	youtubeFindEndpoint     string = "https://www.googleapis.com/youtube/v3/videos?part=snippet,contentDetails&key=%s&id=%s"
+	youtubeSearchEndpoint   string = "https://www.googleapis.com/youtube/v3/search?part=snippet&type=video&key=%s&q=%s&fields=items(id)"
+	youtubePlaylistEndpoint string = "https://www.googleapis.com/youtube/v3/playlistItems?part=snippet,contentDetails&key=%s&playlistId=%s&maxResults=50&fields=items(snippet)"
+)
+
+type youtubeResponseFind struct {
+	Items []itemsFind
+}
+
+type itemsFind struct {
+	Snippet        snippet
+	ID             string
+	ContentDetails contentDetails
+}
+
+type youtubeResponseSearch struct {
+	Items []itemsSearch
+}
+
+type itemsSearch struct {
+	ID id
+}
+
+type id struct {
+	VideoId string
+}
+
+type snippet struct {
+	Title        string
+	Thumbnails   thumbnails
+	ChannelTitle string
+}
+
+type thumbnails struct {
+	Standard standard
+}
+
+type standard struct {
+	Url    string
+	Width  int
+	Height int
+}
+
+type contentDetails struct {
+	Duration string
+}
+
+// youtubeVideoInfo is the metadata of a video. Cached to save API calls when the same video is played again
+type youtubeVideoInfo struct {
+	title       string
+	thumbnail   string
+	channelName string
+	duration    time.Duration
+}
+
+// The cache is cleared once it is full
+const maxCachedVideoInfo = 500
+
+var (
+	videoInfoCache      = map[string]youtubeVideoInfo{}
+	videoInfoCacheMutex sync.Mutex
 )
 
+// Returns the title, thumbnail, channel and duration of a youtube video
+// error if there was any problem
+func youtubeFindByVideoID(videoID string) (string, string, string, time.Duration, error) {
+
+	videoInfoCacheMutex.Lock()
+	info, ok := videoInfoCache[videoID]
+	videoInfoCacheMutex.Unlock()
+
+	if ok {
+		return info.title, info.thumbnail, info.channelName, info.duration, nil
+	}
+
+	res, err := http.Get(fmt.Sprintf(youtubeFindEndpoint, config.CONFIG.Music.YoutubeAPIKey, videoID))
+	if err != nil {
+		return "", "", "", 0, err
+	} else if res.StatusCode != 200 {
+		return "", "", "", 0, fmt.Errorf(errStatusYTSearchVideoID, res.StatusCode, videoID)
+	}
+	defer res.Body.Close()
+
+	var page youtubeResponseFind
+
+	err = json.NewDecoder(res.Body).Decode(&page)
+	if err != nil {
+		return "", "", "", 0, err
+	}
+
+	if len(page.Items) == 0 {
+		return "", "", "", 0, errEmptyYTResult
+	}
+
+	title := page.Items[0].Snippet.Title
+	thumbnail := page.Items[0].Snippet.Thumbnails.Standard.Url
+	channelName := page.Items[0].Snippet.ChannelTitle
+
+	duration, err := parseYoutubeDuration(page.Items[0].ContentDetails.Duration)
+	if err != nil {
+		return "", "", "", 0, err
+	}
+
+	videoInfoCacheMutex.Lock()
+	if len(videoInfoCache) >= maxCachedVideoInfo {
+		videoInfoCache = map[string]youtubeVideoInfo{}
+	}
+	videoInfoCache[videoID] = youtubeVideoInfo{title, thumbnail, channelName, duration}
+	videoInfoCacheMutex.Unlock()
+
+	return title, thumbnail, channelName, duration, nil
+}
+
+func youtubeSearch(query string) (string, string, string, string, time.Duration, error) {
+
+	query = url.QueryEscape(query)
+	res, err := http.Get(fmt.Sprintf(youtubeSearchEndpoint, config.CONFIG.Music.YoutubeAPIKey, query))
+	if err != nil {
+		return "", "", "", "", 0, err
+	} else if res.StatusCode != 200 {
+		return "", "", "", "", 0, fmt.Errorf(errStatusYTSearchQuery, res.StatusCode, query)
+	}
+	defer res.Body.Close()
+
+	var page youtubeResponseSearch
+
+	err = json.NewDecoder(res.Body).Decode(&page)
+	if err != nil {
+		return "", "", "", "", 0, err
+	}
+
+	if len(page.Items) == 0 {
+		return "", "", "", "", 0, errEmptyYTResult
+	}
+
+	videoID := page.Items[0].ID.VideoId
+
+	title, thumbnail, channelName, duration, err := youtubeFindByVideoID(videoID)
+	if err != nil {
+		return "", "", "", "", 0, err
+	}
+
+	return title, thumbnail, channelName, videoID, duration, nil
+}
+
+// parseYoutubeDuration parses the ISO-8601 duration string youtube uses
+// P1DT1H24M47S -> 25 hours 24 minutes and 47 seconds
+// Live streams have the duration P0D which results in a duration of 0
+func parseYoutubeDuration(input string) (time.Duration, error) {
+
+	if !strings.HasPrefix(input, "P") {
+		return 0, fmt.Errorf(errInvalidYTDuration, input)
+	}
+
+	var duration time.Duration
+	var number strings.Builder
+	timePart := false
+
+	for _, char := range input[1:] {
+
+		if char >= '0' && char <= '9' {
+			number.WriteRune(char)
+			continue
+		}
+
+		if char == 'T' {
+			timePart = true
+			continue
+		}
+
+		value, err := strconv.Atoi(number.String())
+		if err != nil {
+			return 0, fmt.Errorf(errInvalidYTDuration, input)
+		}
+		number.Reset()
+
+		var unit time.Duration
+		switch {
+		case char == 'W' && !timePart:
+			unit = time.Hour * 24 * 7
+		case char == 'D' && !timePart:
+			unit = time.Hour * 24
+		case char == 'H' && timePart:
+			unit = time.Hour
+		case char == 'M' && timePart:
+			unit = time.Minute
+		case char == 'S' && timePart:
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf(errInvalidYTDuration, input)
+		}
+
+		duration += time.Duration(value) * unit
+	}
+
+	// Leftover digits without a unit
+	if number.Len() > 0 {
+		return 0, fmt.Errorf(errInvalidYTDuration, input)
+	}
+
+	return duration, nil
+}
+
 type videoResponse struct {
 	Formats []struct {
 		Url string `json:"url"`
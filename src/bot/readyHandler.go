@@ -3,6 +3,7 @@ package bot
 import (
 	"fmt"
 
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/music"
 	"github.com/CarlFlo/malm"
 	"github.com/bwmarrin/discordgo"
 )
@@ -18,4 +19,6 @@ func readyHandler(s *discordgo.Session, ready *discordgo.Ready) {
 	s.UpdateStreamingStatus(0, statusMessage, "https://www.youtube.com/watch?v=3glxLWVkbSs")
 	// Normal message
 	//s.UpdateGameStatus(0, statusMessage)
+
+	music.OfferResumeSessions(s)
 }
@@ -0,0 +1,12 @@
+package bot
+
+import (
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/music"
+	"github.com/bwmarrin/discordgo"
+)
+
+func voiceStateUpdateHandler(s *discordgo.Session, vsu *discordgo.VoiceStateUpdate) {
+
+	// For leaving empty voice channels
+	music.VoiceStateUpdate(s, vsu)
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"io/ioutil"
 	"time"
@@ -28,6 +29,11 @@ type configStruct struct {
 	Economy             economy           `json:"economy"`
 	Work                work              `json:"work"`
 	Daily               daily             `json:"daily"`
+	StreakFreeze        streakFreeze      `json:"streakFreeze"`
+	Levels              levels            `json:"levels"`
+	Profile             profile           `json:"profile"`
+	Gambling            gambling          `json:"gambling"`
+	Lottery             lottery           `json:"lottery"`
 	Farm                farm              `json:"farm"`
 	Colors              colors            `json:"colors"`
 	Emojis              emojis            `json:"emojis"`
@@ -47,6 +53,18 @@ type notifySettings struct {
 type music struct {
 	YoutubeAPIKey        string `json:"youtubeAPIKey"`
 	MaxSongLengthMinutes int    `json:"maxSongLengthMinutes"`
+	MusicDirectory       string `json:"musicDirectory"`
+	AllowDirectURLs      bool   `json:"allowDirectURLs"`
+	// Leaves the voice channel after this many minutes without music or listeners
+	IdleDisconnectMinutes int `json:"idleDisconnectMinutes"`
+	DefaultVolume         int `json:"defaultVolume"`  // In percent, 0 - 200
+	DefaultBitrate        int `json:"defaultBitrate"` // In kb/s, 8 - 128
+	// Users with this role can skip, stop and clear the queue without voting
+	DJRoleName string `json:"djRoleName"`
+	// Saves the queues so the music can be resumed after a restart
+	ResumeSessions bool `json:"resumeSessions"`
+	// Folder with .txt or .lrc files named after the songs
+	LyricsDirectory string `json:"lyricsDirectory"`
 }
 
 type messageProcessing struct {
@@ -70,18 +88,47 @@ type economy struct {
 }
 
 type work struct {
+	Jobs                      []job    `json:"jobs"`                     // The first job is the one everyone starts with
+	PromotionExperience       int      `json:"promotionExperience"`      // Experience needed for each promotion
+	PromotionPayBonus         float64  `json:"promotionPayBonus"`        // Added to the pay multiplier for each promotion
+	Tools                     []tool   `json:"tools"`                    // At most 5 tools, one row of buttons each
+	ToolRepairCostMultiplier  float64  `json:"toolRepairCostMultiplier"` // Repairing a fully broken tool costs the tier price times this
+	StreakOutput              []string `json:"streakOutput"`
+	StreakBonus               int      `json:"streakBonus"`
+	StreakResetHours          int      `json:"streakResetHours"`
+	MiniGames                 bool     `json:"miniGames"` // The user has to solve a short task to get paid
+	MiniGameSeconds           int      `json:"miniGameSeconds"`
+	MiniGameCorrectMultiplier float64  `json:"miniGameCorrectMultiplier"`
+	MiniGameWrongMultiplier   float64  `json:"miniGameWrongMultiplier"`
+	MiniGameTimeoutMultiplier float64  `json:"miniGameTimeoutMultiplier"`
+}
+type job struct {
+	Name     string `json:"name"`
+	Emoji    string `json:"emoji"`
+	MinMoney int    `json:"minMoney"`
+	MaxMoney int    `json:"maxMoney"`
 	// Cooldown in hours
-	Cooldown                time.Duration `json:"cooldown"`
-	MinMoney                int           `json:"minMoney"`
-	MaxMoney                int           `json:"maxMoney"`
-	ToolBonus               int           `json:"toolBonus"`
-	ToolBasePrice           int           `json:"toolBasePrice"`
-	ToolBasePriceMultiplier float64       `json:"toolBasePriceMultiplier"`
-	MaxTools                uint8         `json:"maxTools"`
-	StreakOutput            []string      `json:"streakOutput"`
-	StreakBonus             int           `json:"streakBonus"`
-	StreakResetHours        int           `json:"streakResetHours"`
+	Cooldown       time.Duration `json:"cooldown"`
+	Experience     int           `json:"experience"`     // Earned each shift
+	RequiredShifts int           `json:"requiredShifts"` // Shifts worked in any job before the job can be taken
+	Ranks          []string      `json:"ranks"`          // The title for each promotion, starting with the first
+}
+
+// Each tool is bought at the first tier and upgraded through the rest
+type tool struct {
+	Name  string     `json:"name"`
+	Emoji string     `json:"emoji"`
+	Tiers []toolTier `json:"tiers"`
 }
+
+type toolTier struct {
+	Name       string  `json:"name"`
+	Price      int     `json:"price"`      // The price to buy or upgrade to the tier
+	Bonus      int     `json:"bonus"`      // Added to each paycheck
+	PayBonus   float64 `json:"payBonus"`   // Added to the pay multiplier
+	Durability int     `json:"durability"` // Shifts before the tool breaks
+}
+
 type daily struct {
 	// Cooldown in hours
 	Cooldown         time.Duration `json:"cooldown"`
@@ -94,13 +141,78 @@ type daily struct {
 
 type farm struct {
 	DefaultOwnedFarmPlots       uint8         `json:"defaultOwnedFarmPlots"`
-	CropSeedPrice               int           `json:"cropSeedPrice"`
+	CropsFile                   string        `json:"cropsFile"` // The crops are defined in this file
 	FarmPlotPrice               int           `json:"farmPlotPrice"`
 	FarmPlotCostMultiplier      float64       `json:"farmPlotCostMultiplier"`
 	MaxPlots                    uint8         `json:"maxPlots"`
 	WaterCooldown               time.Duration `json:"waterCooldown"`
 	WaterCropTimeReductionHours time.Duration `json:"waterCropTimeReductionHours"`
 	CropsPreishAfter            time.Duration `json:"cropsPreishAfter"`
+	FertilizerPrice             int           `json:"fertilizerPrice"`
+	FertilizerYieldMultiplier   float64       `json:"fertilizerYieldMultiplier"`
+	SprinklerPrice              int           `json:"sprinklerPrice"`
+	SprinklerDurationHours      int           `json:"sprinklerDurationHours"`
+	ScarecrowPrice              int           `json:"scarecrowPrice"`
+	CropLossChance              float64       `json:"cropLossChance"`      // Chance for crows to eat a crop when harvesting. 0 - 1
+	ScarecrowProtection         float64       `json:"scarecrowProtection"` // How much of the crop loss chance the scarecrow removes. 0 - 1
+	SeasonLengthDays            int           `json:"seasonLengthDays"`    // 0 to always be spring
+	WeatherChangeHours          int           `json:"weatherChangeHours"`  // 0 to always be sunny
+	InSeasonRewardMultiplier    float64       `json:"inSeasonRewardMultiplier"`
+	InSeasonGrowthMultiplier    float64       `json:"inSeasonGrowthMultiplier"`
+	HarvestsToUnlockNextCrop    int           `json:"harvestsToUnlockNextCrop"`
+	PrestigeYieldBonus          float64       `json:"prestigeYieldBonus"` // Added to the yield multiplier for each prestige level
+	GuildFarms                  bool          `json:"guildFarms"`         // Lets the members of a server share a farm
+}
+
+// A streak freeze keeps the work or daily streak when the user misses it
+type streakFreeze struct {
+	Price    int   `json:"price"`
+	MaxOwned uint8 `json:"maxOwned"`
+}
+
+// The XP needed to go from level n to n+1 is BaseXP * n^Exponent
+type levels struct {
+	BaseXP         int           `json:"baseXP"`
+	Exponent       float64       `json:"exponent"`
+	WorkXP         int           `json:"workXP"`
+	DailyXP        int           `json:"dailyXP"`
+	HarvestXP      int           `json:"harvestXP"`      // For each crop harvested
+	MusicXP        int           `json:"musicXP"`        // For each song queued
+	RewardPerLevel int           `json:"rewardPerLevel"` // Times the level reached
+	Rewards        []levelReward `json:"rewards"`        // Extra rewards for reaching some levels
+}
+
+type levelReward struct {
+	Level         int   `json:"level"`
+	Money         int   `json:"money"`
+	StreakFreezes uint8 `json:"streakFreezes"`
+}
+
+type profile struct {
+	CardImage    bool `json:"cardImage"` // Attaches a generated image of the profile
+	MaxBioLength int  `json:"maxBioLength"`
+}
+
+// The house edge is the share of the winnings the house keeps, 0 - 1. Losses and pushes are not affected
+type gambling struct {
+	MinBet                  int     `json:"minBet"`
+	MaxBet                  int     `json:"maxBet"`
+	CooldownSeconds         int     `json:"cooldownSeconds"` // Between two games of the same kind
+	CoinflipHouseEdge       float64 `json:"coinflipHouseEdge"`
+	SlotsHouseEdge          float64 `json:"slotsHouseEdge"`
+	BlackjackHouseEdge      float64 `json:"blackjackHouseEdge"`
+	BlackjackTimeoutSeconds int     `json:"blackjackTimeoutSeconds"` // The player stands if they don't act in time
+	DuelTimeoutSeconds      int     `json:"duelTimeoutSeconds"`      // The stakes are returned if a duel is not accepted or finished in time
+}
+
+// The lottery is drawn at an interval. Every ticket has the same chance to win the pot
+type lottery struct {
+	TicketPrice         int     `json:"ticketPrice"`
+	MaxTicketsPerUser   int     `json:"maxTicketsPerUser"` // In each draw
+	StartingPot         int     `json:"startingPot"`       // Added by the house to each new pot
+	HouseCut            float64 `json:"houseCut"`          // The share of the ticket sales that does not go to the pot, 0 - 1
+	DrawIntervalHours   int     `json:"drawIntervalHours"`
+	AnnouncementChannel string  `json:"announcementChannel"` // The ID of the channel the draws are announced in. Leave empty to only message the winner
 }
 
 type colors struct {
@@ -123,12 +235,19 @@ type emojis struct {
 	MusicNotes          string              `json:"musicNotes"`
 	MusicPlaying        string              `json:"musicPlaying"`
 	MusicPaused         string              `json:"musicPaused"`
+	SeedStage           string              `json:"seedStage"`
+	SproutStage         string              `json:"sproutStage"`
+	GrowingStage        string              `json:"growingStage"`
+	StreakFreeze        string              `json:"streakFreeze"`
 }
 
 type componentEmojiNames struct {
 	MoneyBag string `json:"moneyBag"`
 	Help     string `json:"help"`
 	Refresh  string `json:"refresh"`
+	Freeze   string `json:"freeze"`
+	Repair   string `json:"repair"`
+	Upgrade  string `json:"upgrade"`
 }
 
 // ReloadConfig is a wrapper function for reloading the config. For clarity
@@ -145,18 +264,128 @@ func readConfig() error {
 		return err
 	}
 
-	if err = json.Unmarshal(file, &CONFIG); err != nil {
+	merged, err := mergeWithDefaults(file)
+	if err != nil {
 		return err
 	}
 
+	// A new struct is used, so a reload does not mix the old values into the new ones
+	var loaded configStruct
+	if err = json.Unmarshal(merged, &loaded); err != nil {
+		return err
+	}
+
+	replaceInvalidValues(&loaded, defaultConfig())
+
+	CONFIG = &loaded
 	return nil
 }
 
+// replaceInvalidValues replaces the values that have to be above 0 with the defaults
+func replaceInvalidValues(c *configStruct, defaults configStruct) {
+
+	if c.Farm.FertilizerYieldMultiplier <= 0 {
+		malm.Warn("The fertilizer yield multiplier in the config file has to be above 0. Using the default")
+		c.Farm.FertilizerYieldMultiplier = defaults.Farm.FertilizerYieldMultiplier
+	}
+
+	if c.Farm.InSeasonRewardMultiplier <= 0 {
+		malm.Warn("The in season reward multiplier in the config file has to be above 0. Using the default")
+		c.Farm.InSeasonRewardMultiplier = defaults.Farm.InSeasonRewardMultiplier
+	}
+
+	if c.Farm.HarvestsToUnlockNextCrop <= 0 {
+		malm.Warn("The number of harvests to unlock the next crop in the config file has to be above 0. Using the default")
+		c.Farm.HarvestsToUnlockNextCrop = defaults.Farm.HarvestsToUnlockNextCrop
+	}
+
+	if len(c.Farm.CropsFile) == 0 {
+		malm.Warn("No crops file provided in the config file. Using the default")
+		c.Farm.CropsFile = defaults.Farm.CropsFile
+	}
+
+	if c.Profile.MaxBioLength <= 0 {
+		malm.Warn("The maximum bio length in the config file has to be above 0. Using the default")
+		c.Profile.MaxBioLength = defaults.Profile.MaxBioLength
+	}
+
+	if c.Gambling.DuelTimeoutSeconds <= 0 {
+		malm.Warn("The duel timeout in the config file has to be above 0. Using the default")
+		c.Gambling.DuelTimeoutSeconds = defaults.Gambling.DuelTimeoutSeconds
+	}
+
+	if c.Lottery.MaxTicketsPerUser <= 0 {
+		malm.Warn("The maximum lottery tickets per user in the config file has to be above 0. Using the default")
+		c.Lottery.MaxTicketsPerUser = defaults.Lottery.MaxTicketsPerUser
+	}
+
+	if c.Work.MiniGameSeconds <= 0 {
+		malm.Warn("The mini game time in the config file has to be above 0. Using the default")
+		c.Work.MiniGameSeconds = defaults.Work.MiniGameSeconds
+	}
+
+	if c.Gambling.BlackjackTimeoutSeconds <= 0 {
+		malm.Warn("The blackjack timeout in the config file has to be above 0. Using the default")
+		c.Gambling.BlackjackTimeoutSeconds = defaults.Gambling.BlackjackTimeoutSeconds
+	}
+}
+
+// mergeWithDefaults fills everything missing from the config file with the default settings,
+// so a config file from an older version still works. Lists from the file replace the default lists
+func mergeWithDefaults(file []byte) ([]byte, error) {
+
+	defaults, err := json.Marshal(defaultConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	var base, overrides map[string]interface{}
+	if err = decodeJSONObject(defaults, &base); err != nil {
+		return nil, err
+	}
+	if err = decodeJSONObject(file, &overrides); err != nil {
+		return nil, err
+	}
+
+	mergeJSONObjects(base, overrides)
+	return json.Marshal(base)
+}
+
+// decodeJSONObject keeps the numbers as they are written, so large IDs don't lose precision
+func decodeJSONObject(data []byte, object *map[string]interface{}) error {
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(object)
+}
+
+// mergeJSONObjects copies the overrides into the base. Objects are merged, everything else is replaced
+func mergeJSONObjects(base, overrides map[string]interface{}) {
+
+	for key, value := range overrides {
+		if baseObject, ok := base[key].(map[string]interface{}); ok {
+			if object, ok := value.(map[string]interface{}); ok {
+				mergeJSONObjects(baseObject, object)
+				continue
+			}
+		}
+		base[key] = value
+	}
+}
+
 // createConfig creates the default config file
 func createConfig() error {
 
-	// Default config settings
-	configStruct := configStruct{
+	jsonData, _ := json.MarshalIndent(defaultConfig(), "", "   ")
+	err := ioutil.WriteFile("config.json", jsonData, 0644)
+
+	return err
+}
+
+// defaultConfig returns the default config settings
+func defaultConfig() configStruct {
+
+	return configStruct{
 		Token:               "",
 		BotPrefix:           ",",
 		OwnerID:             "",
@@ -172,8 +401,16 @@ func createConfig() error {
 			CheckInterval: 5,
 		},
 		Music: music{
-			YoutubeAPIKey:        "",
-			MaxSongLengthMinutes: 12,
+			YoutubeAPIKey:         "",
+			MaxSongLengthMinutes:  12,
+			MusicDirectory:        "", // Leave empty to disable playing local files
+			AllowDirectURLs:       false,
+			IdleDisconnectMinutes: 5, // Set to 0 to never leave
+			DefaultVolume:         100,
+			DefaultBitrate:        64,
+			DJRoleName:            "DJ", // Leave empty to let everyone control the music
+			ResumeSessions:        true,
+			LyricsDirectory:       "", // Leave empty to disable lyrics
 		},
 		MessageProcessing: messageProcessing{
 			MessageLengthLimit:    1850, // The meximum length a send message can be before it will be split.
@@ -187,16 +424,89 @@ func createConfig() error {
 			StartingMoney: 0,
 		},
 		Work: work{
-			Cooldown:                6,
-			MinMoney:                100,
-			MaxMoney:                250,
-			ToolBonus:               45,
-			ToolBasePrice:           50,
-			ToolBasePriceMultiplier: 1.25,
-			MaxTools:                20,
-			StreakOutput:            []string{":regional_indicator_b:", ":regional_indicator_o:", ":regional_indicator_n:", ":regional_indicator_u:", ":regional_indicator_s:"},
-			StreakBonus:             350,
-			StreakResetHours:        24,
+			Jobs: []job{
+				{
+					Name:       "Cashier",
+					Emoji:      ":shopping_cart:",
+					MinMoney:   100,
+					MaxMoney:   250,
+					Cooldown:   6,
+					Experience: 20,
+					Ranks:      []string{"Trainee Cashier", "Cashier", "Head Cashier", "Store Manager"},
+				}, {
+					Name:       "Courier",
+					Emoji:      ":package:",
+					MinMoney:   50,
+					MaxMoney:   120,
+					Cooldown:   3,
+					Experience: 10,
+					Ranks:      []string{"Bike Courier", "Courier", "Senior Courier", "Dispatcher"},
+				}, {
+					Name:           "Chef",
+					Emoji:          ":cook:",
+					MinMoney:       250,
+					MaxMoney:       450,
+					Cooldown:       8,
+					Experience:     20,
+					RequiredShifts: 15,
+					Ranks:          []string{"Line Cook", "Sous Chef", "Head Chef", "Executive Chef"},
+				}, {
+					Name:           "Programmer",
+					Emoji:          ":computer:",
+					MinMoney:       400,
+					MaxMoney:       700,
+					Cooldown:       10,
+					Experience:     25,
+					RequiredShifts: 40,
+					Ranks:          []string{"Intern", "Junior Developer", "Developer", "Senior Developer", "Tech Lead"},
+				}, {
+					Name:           "Doctor",
+					Emoji:          ":stethoscope:",
+					MinMoney:       700,
+					MaxMoney:       1200,
+					Cooldown:       12,
+					Experience:     25,
+					RequiredShifts: 80,
+					Ranks:          []string{"Medical Student", "Resident", "Doctor", "Specialist", "Chief of Medicine"},
+				},
+			},
+			PromotionExperience: 100,
+			PromotionPayBonus:   0.15,
+			Tools: []tool{
+				{
+					Name:  "Hammer",
+					Emoji: ":hammer:",
+					Tiers: []toolTier{
+						{Name: "Wooden Hammer", Price: 150, Bonus: 30, Durability: 20},
+						{Name: "Iron Hammer", Price: 600, Bonus: 80, Durability: 35},
+						{Name: "Steel Hammer", Price: 2000, Bonus: 180, Durability: 50},
+					},
+				}, {
+					Name:  "Bicycle",
+					Emoji: ":bike:",
+					Tiers: []toolTier{
+						{Name: "Old Bicycle", Price: 300, PayBonus: 0.05, Durability: 25},
+						{Name: "Road Bike", Price: 1200, PayBonus: 0.1, Durability: 40},
+						{Name: "Electric Bike", Price: 4000, PayBonus: 0.2, Durability: 60},
+					},
+				}, {
+					Name:  "Phone",
+					Emoji: ":mobile_phone:",
+					Tiers: []toolTier{
+						{Name: "Flip Phone", Price: 250, Bonus: 20, PayBonus: 0.02, Durability: 30},
+						{Name: "Smartphone", Price: 1500, Bonus: 60, PayBonus: 0.05, Durability: 50},
+					},
+				},
+			},
+			ToolRepairCostMultiplier:  0.5,
+			StreakOutput:              []string{":regional_indicator_b:", ":regional_indicator_o:", ":regional_indicator_n:", ":regional_indicator_u:", ":regional_indicator_s:"},
+			StreakBonus:               350,
+			StreakResetHours:          24,
+			MiniGames:                 true,
+			MiniGameSeconds:           20,
+			MiniGameCorrectMultiplier: 1.25,
+			MiniGameWrongMultiplier:   0.5,
+			MiniGameTimeoutMultiplier: 0.25,
 		},
 		Daily: daily{
 			Cooldown:         24,
@@ -206,15 +516,69 @@ func createConfig() error {
 			StreakBonus:      2000,
 			StreakResetHours: 24,
 		},
+		StreakFreeze: streakFreeze{
+			Price:    2500,
+			MaxOwned: 3,
+		},
+		Levels: levels{
+			BaseXP:         100,
+			Exponent:       1.5,
+			WorkXP:         25,
+			DailyXP:        40,
+			HarvestXP:      10,
+			MusicXP:        0,
+			RewardPerLevel: 100,
+			Rewards: []levelReward{
+				{Level: 5, Money: 2000, StreakFreezes: 1},
+				{Level: 10, Money: 5000, StreakFreezes: 1},
+				{Level: 25, Money: 20000, StreakFreezes: 2},
+			},
+		},
+		Profile: profile{
+			CardImage:    true,
+			MaxBioLength: 150,
+		},
+		Gambling: gambling{
+			MinBet:                  10,
+			MaxBet:                  10000,
+			CooldownSeconds:         5,
+			CoinflipHouseEdge:       0.05,
+			SlotsHouseEdge:          0.05,
+			BlackjackHouseEdge:      0,
+			BlackjackTimeoutSeconds: 60,
+			DuelTimeoutSeconds:      120,
+		},
+		Lottery: lottery{
+			TicketPrice:         100,
+			MaxTicketsPerUser:   50,
+			StartingPot:         1000,
+			HouseCut:            0.1,
+			DrawIntervalHours:   24,
+			AnnouncementChannel: "",
+		},
 		Farm: farm{
 			DefaultOwnedFarmPlots:       1,
-			CropSeedPrice:               50,
+			CropsFile:                   "crops.json",
 			FarmPlotPrice:               5000,
 			FarmPlotCostMultiplier:      1.4,
 			MaxPlots:                    12,
 			WaterCooldown:               2,
 			WaterCropTimeReductionHours: 1,
 			CropsPreishAfter:            24,
+			FertilizerPrice:             750,
+			FertilizerYieldMultiplier:   1.5,
+			SprinklerPrice:              1500,
+			SprinklerDurationHours:      24,
+			ScarecrowPrice:              4000,
+			CropLossChance:              0.1,
+			ScarecrowProtection:         0.75,
+			SeasonLengthDays:            7,
+			WeatherChangeHours:          6,
+			InSeasonRewardMultiplier:    1.5,
+			InSeasonGrowthMultiplier:    1.25,
+			HarvestsToUnlockNextCrop:    3,
+			PrestigeYieldBonus:          0.25,
+			GuildFarms:                  true,
 		},
 		Colors: colors{
 			Success: 0x198754,
@@ -226,6 +590,9 @@ func createConfig() error {
 				MoneyBag: "💰",
 				Help:     "💡", // Alt: 💡, ❔
 				Refresh:  "🔄",
+				Freeze:   "🧊",
+				Repair:   "🔧",
+				Upgrade:  "⬆️",
 			},
 			Bank:         ":bank:",
 			Wallet:       ":dollar:",
@@ -239,6 +606,10 @@ func createConfig() error {
 			MusicNotes:   ":musical_note:",
 			MusicPlaying: ":arrow_forward:",
 			MusicPaused:  ":pause_button:",
+			SeedStage:    ":chestnut:",
+			SproutStage:  ":seedling:",
+			GrowingStage: ":herb:",
+			StreakFreeze: ":ice_cube:",
 		},
 		Debug: debug{
 			IgnoreWorkCooldown:  false,
@@ -246,11 +617,6 @@ func createConfig() error {
 			IgnoreWaterCooldown: false,
 		},
 	}
-
-	jsonData, _ := json.MarshalIndent(configStruct, "", "   ")
-	err := ioutil.WriteFile("config.json", jsonData, 0644)
-
-	return err
 }
 
 // loadConfiguration loads the configuration file into memory
@@ -299,6 +665,52 @@ func requiredVariableCheck() {
 		problem = true
 	}
 
+	if len(CONFIG.Work.Jobs) == 0 {
+		malm.Error("No jobs provided in the config file! At least one job is needed for the work command")
+		problem = true
+	}
+
+	for _, j := range CONFIG.Work.Jobs {
+		if j.MinMoney < 0 || j.MaxMoney < j.MinMoney {
+			malm.Error("The job '%s' in the config file has to pay at least 0, and the maximum pay can't be below the minimum pay!", j.Name)
+			problem = true
+		}
+	}
+
+	if len(CONFIG.Work.Tools) > 5 {
+		malm.Error("Too many tools provided in the config file! There can be at most 5 tools")
+		problem = true
+	}
+
+	for _, t := range CONFIG.Work.Tools {
+		if len(t.Tiers) == 0 {
+			malm.Error("The tool '%s' has no tiers in the config file! At least one tier is needed", t.Name)
+			problem = true
+		}
+	}
+
+	if CONFIG.Gambling.MinBet <= 0 || CONFIG.Gambling.MinBet > CONFIG.Gambling.MaxBet {
+		malm.Error("The minimum bet in the config file has to be above 0 and not above the maximum bet!")
+		problem = true
+	}
+
+	for _, edge := range []float64{CONFIG.Gambling.CoinflipHouseEdge, CONFIG.Gambling.SlotsHouseEdge, CONFIG.Gambling.BlackjackHouseEdge} {
+		if edge < 0 || edge > 1 {
+			malm.Error("A house edge in the config file is outside of 0 - 1!")
+			problem = true
+		}
+	}
+
+	if CONFIG.Lottery.TicketPrice <= 0 || CONFIG.Lottery.DrawIntervalHours <= 0 {
+		malm.Error("The lottery ticket price and draw interval in the config file have to be above 0!")
+		problem = true
+	}
+
+	if CONFIG.Lottery.HouseCut < 0 || CONFIG.Lottery.HouseCut > 1 {
+		malm.Error("The lottery house cut in the config file is outside of 0 - 1!")
+		problem = true
+	}
+
 	if problem {
 		malm.Fatal("There are at least one variable missing in the configuration file. Please fix the above errors!")
 	}
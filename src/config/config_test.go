@@ -0,0 +1,62 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+func TestMergeWithDefaults(t *testing.T) {
+
+	file := []byte(`{"token": "abc", "work": {"jobs": [{"name": "Tester", "minMoney": 1, "maxMoney": 2}]}, "gambling": {"minBet": 5}}`)
+
+	merged, err := mergeWithDefaults(file)
+	if err != nil {
+		t.Fatalf("Could not merge the config: %s", err)
+	}
+
+	var c configStruct
+	if err = json.Unmarshal(merged, &c); err != nil {
+		t.Fatalf("Could not read the merged config: %s", err)
+	}
+
+	defaults := defaultConfig()
+
+	test.Validate(t, c.Token, "abc", "The token from the file was not kept")
+	test.Validate(t, c.BotPrefix, defaults.BotPrefix, "The missing prefix was not set to the default")
+	test.Validate(t, c.Gambling.MinBet, 5, "The bet from the file was not kept")
+	test.Validate(t, c.Gambling.MaxBet, defaults.Gambling.MaxBet, "The missing bet was not set to the default")
+	test.Validate(t, c.Lottery.TicketPrice, defaults.Lottery.TicketPrice, "The missing lottery section was not set to the default")
+	test.Validate(t, len(c.Work.Jobs), 1, "The jobs from the file did not replace the default jobs")
+	test.Validate(t, c.Work.Jobs[0].Emoji, "", "The job from the file got a value from a default job")
+	test.Validate(t, len(c.Work.Tools), len(defaults.Work.Tools), "The missing tools were not set to the default")
+}
+
+func TestReplaceInvalidValues(t *testing.T) {
+
+	defaults := defaultConfig()
+
+	c := defaultConfig()
+	c.Farm.FertilizerYieldMultiplier = 0
+	c.Farm.InSeasonRewardMultiplier = 0
+	c.Farm.HarvestsToUnlockNextCrop = 0
+	c.Farm.CropsFile = ""
+	c.Profile.MaxBioLength = 0
+	c.Gambling.DuelTimeoutSeconds = 0
+	c.Lottery.MaxTicketsPerUser = 0
+	c.Work.MiniGameSeconds = 0
+	c.Gambling.BlackjackTimeoutSeconds = 0
+
+	replaceInvalidValues(&c, defaults)
+
+	test.Validate(t, c.Farm.FertilizerYieldMultiplier, defaults.Farm.FertilizerYieldMultiplier, "The fertilizer yield multiplier was not set to the default")
+	test.Validate(t, c.Farm.InSeasonRewardMultiplier, defaults.Farm.InSeasonRewardMultiplier, "The in season reward multiplier was not set to the default")
+	test.Validate(t, c.Farm.HarvestsToUnlockNextCrop, defaults.Farm.HarvestsToUnlockNextCrop, "The harvests to unlock the next crop were not set to the default")
+	test.Validate(t, c.Farm.CropsFile, defaults.Farm.CropsFile, "The crops file was not set to the default")
+	test.Validate(t, c.Profile.MaxBioLength, defaults.Profile.MaxBioLength, "The maximum bio length was not set to the default")
+	test.Validate(t, c.Gambling.DuelTimeoutSeconds, defaults.Gambling.DuelTimeoutSeconds, "The duel timeout was not set to the default")
+	test.Validate(t, c.Lottery.MaxTicketsPerUser, defaults.Lottery.MaxTicketsPerUser, "The maximum lottery tickets per user were not set to the default")
+	test.Validate(t, c.Work.MiniGameSeconds, defaults.Work.MiniGameSeconds, "The mini game time was not set to the default")
+	test.Validate(t, c.Gambling.BlackjackTimeoutSeconds, defaults.Gambling.BlackjackTimeoutSeconds, "The blackjack timeout was not set to the default")
+}
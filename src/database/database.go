@@ -20,6 +20,14 @@ func Connect() {
 		return
 	}
 	malm.Info("Connected to database")
+
+	if err := SyncCrops(); err != nil {
+		malm.Fatal("Could not load the crops: %s", err)
+	}
+
+	RefundOpenDuels()
+	RefundOpenBlackjackHands()
+	PayOpenWorkShifts()
 }
 
 func connectToDB() error {
@@ -35,14 +43,32 @@ func connectToDB() error {
 	var modelList = []interface{}{
 		&User{},
 		&Work{},
+		&WorkTool{},
 		&Daily{},
 		&Farm{},
 		&FarmPlot{},
 		&FarmCrop{},
+		&FarmCropHarvest{},
+		&GuildFarm{},
+		&GuildFarmContribution{},
+		&GuildFarmLog{},
+		&UserAchievement{},
+		&MoneyHistory{},
+		&Duel{},
+		&BlackjackHand{},
+		&WorkShift{},
 		&Notify{},
 		&Debug{},
 	}
 
+	// These tables are kept when the database is reset, so the music and the lottery survive restarts
+	var persistentModelList = []interface{}{
+		&MusicSession{},
+		&MusicSessionSong{},
+		&Lottery{},
+		&LotteryTicket{},
+	}
+
 	if resetDatabaseOnStart {
 
 		malm.Info("Resetting database...")
@@ -59,5 +85,5 @@ func connectToDB() error {
 	}
 
 	// Remeber to add new tables to the tableList and not just here!
-	return DB.AutoMigrate(modelList...)
+	return DB.AutoMigrate(append(modelList, persistentModelList...)...)
 }
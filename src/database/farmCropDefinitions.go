@@ -0,0 +1,209 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/malm"
+)
+
+/*
+	The crops are defined in a JSON file so they can be balanced without changing the code.
+	The file is created with the default crops if it does not exist.
+	Crops are unlocked in the order they are listed in the file. The plots and the harvests refer to the crops by their position,
+	so crops can only be added or removed at the end of the file once the bot has been started
+*/
+
+// CropDefinition is how a crop is written in the crops file
+type CropDefinition struct {
+	Name             string   `json:"name"`
+	Emoji            string   `json:"emoji"`
+	GrowTime         string   `json:"growTime"` // e.g. "20m", "6h" or "72h"
+	HarvestReward    int      `json:"harvestReward"`
+	SeedPrice        int      `json:"seedPrice"`
+	UnlockHarvests   int      `json:"unlockHarvests"` // Harvests of the previous crop needed to unlock this crop. 0 uses harvestsToUnlockNextCrop
+	PreferredSeasons []string `json:"preferredSeasons"`
+}
+
+// SyncCrops reads the crops file and updates the farmCrops table to match it.
+// The table is left untouched if the file is not valid
+func SyncCrops() error {
+
+	definitions, err := loadCropDefinitions(config.CONFIG.Farm.CropsFile)
+	if err != nil {
+		return err
+	}
+
+	crops, err := validateCropDefinitions(definitions)
+	if err != nil {
+		return fmt.Errorf("%s: %s", config.CONFIG.Farm.CropsFile, err)
+	}
+
+	var stored []FarmCrop
+	DB.Order("id asc").Find(&stored)
+
+	if err := checkCropOrder(stored, crops); err != nil {
+		return fmt.Errorf("%s: %s", config.CONFIG.Farm.CropsFile, err)
+	}
+
+	// The crop ID decides the unlock order, so the IDs follow the order in the file
+	for i := range crops {
+		var existing FarmCrop
+		if DB.First(&existing, i+1).RowsAffected > 0 {
+			crops[i].CreatedAt = existing.CreatedAt
+		}
+		crops[i].ID = uint(i + 1)
+		DB.Save(&crops[i])
+	}
+
+	removeCropsAfter(uint(len(crops)))
+
+	malm.Info("Synced %d crops from %s", len(crops), config.CONFIG.Farm.CropsFile)
+	return nil
+}
+
+// checkCropOrder returns an error if a stored crop has been moved or renamed in the file,
+// since the plots and harvests of that crop would silently become another crop
+func checkCropOrder(stored []FarmCrop, crops []FarmCrop) error {
+
+	for _, s := range stored {
+
+		if s.ID == 0 || s.ID > uint(len(crops)) {
+			continue // Removed from the end of the file
+		}
+
+		if name := crops[s.ID-1].Name; !strings.EqualFold(name, s.Name) {
+			return fmt.Errorf("crop %d was '%s' but is now '%s'. Crops can't be moved or renamed, only added or removed at the end of the file", s.ID, s.Name, name)
+		}
+	}
+	return nil
+}
+
+// removeCropsAfter deletes the crops that were removed from the file, and everything planted with them
+func removeCropsAfter(lastID uint) {
+
+	DB.Where("crop_id > ?", lastID).Delete(&FarmPlot{})
+	DB.Where("crop_id > ?", lastID).Delete(&FarmCropHarvest{})
+	DB.Where("id > ?", lastID).Delete(&FarmCrop{})
+
+	DB.Model(&Farm{}).Where("highest_planted_crop_index > ?", lastID).Update("highest_planted_crop_index", lastID)
+}
+
+// loadCropDefinitions reads the crops file. The file is created with the default crops if it does not exist
+func loadCropDefinitions(path string) ([]CropDefinition, error) {
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := createCropsFile(path); err != nil {
+			return nil, err
+		}
+		malm.Info("Created %s with the default crops", path)
+	}
+
+	file, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var definitions []CropDefinition
+	if err := json.Unmarshal(file, &definitions); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+
+	return definitions, nil
+}
+
+// validateCropDefinitions checks the definitions and converts them into crops
+func validateCropDefinitions(definitions []CropDefinition) ([]FarmCrop, error) {
+
+	if len(definitions) == 0 {
+		return nil, fmt.Errorf("at least one crop must be defined")
+	}
+
+	var crops []FarmCrop
+	names := map[string]bool{}
+
+	for i, d := range definitions {
+
+		name := strings.TrimSpace(d.Name)
+		if len(name) == 0 {
+			return nil, fmt.Errorf("crop %d is missing a name", i+1)
+		}
+
+		if names[strings.ToLower(name)] {
+			return nil, fmt.Errorf("the crop '%s' is defined more than once", name)
+		}
+		names[strings.ToLower(name)] = true
+
+		if len(d.Emoji) == 0 {
+			return nil, fmt.Errorf("the crop '%s' is missing an emoji", name)
+		}
+
+		growTime, err := time.ParseDuration(d.GrowTime)
+		if err != nil {
+			return nil, fmt.Errorf("the crop '%s' has an invalid grow time: %s", name, err)
+		} else if growTime < time.Minute {
+			return nil, fmt.Errorf("the crop '%s' must take at least a minute to grow", name)
+		}
+
+		if d.HarvestReward <= 0 {
+			return nil, fmt.Errorf("the crop '%s' must have a harvest reward above 0", name)
+		} else if d.SeedPrice < 0 {
+			return nil, fmt.Errorf("the crop '%s' can't have a negative seed price", name)
+		} else if d.UnlockHarvests < 0 {
+			return nil, fmt.Errorf("the crop '%s' can't have a negative unlock requirement", name)
+		}
+
+		var seasons []string
+		for _, s := range d.PreferredSeasons {
+			season, ok := ParseSeason(s)
+			if !ok {
+				return nil, fmt.Errorf("the crop '%s' has an unknown season '%s'", name, s)
+			}
+			seasons = append(seasons, season.String())
+		}
+
+		crops = append(crops, FarmCrop{
+			Name:             name,
+			Emoji:            d.Emoji,
+			DurationToGrow:   growTime,
+			HarvestReward:    d.HarvestReward,
+			SeedPrice:        d.SeedPrice,
+			UnlockHarvests:   d.UnlockHarvests,
+			PreferredSeasons: strings.Join(seasons, ","),
+		})
+	}
+
+	return crops, nil
+}
+
+// createCropsFile creates the crops file with the default crops
+func createCropsFile(path string) error {
+
+	// (reward-seedprice) / duration  = ratio
+
+	definitions := []CropDefinition{
+		{Name: "Tomato", Emoji: "🍅", GrowTime: "20m", HarvestReward: 100, SeedPrice: 50, PreferredSeasons: []string{"Summer"}},
+		{Name: "Potato", Emoji: "🥔", GrowTime: "30m", HarvestReward: 120, SeedPrice: 50, PreferredSeasons: []string{"Autumn", "Winter"}},
+		{Name: "Pineapple", Emoji: "🍍", GrowTime: "1h", HarvestReward: 175, SeedPrice: 50, PreferredSeasons: []string{"Summer"}},
+		{Name: "Strawberry", Emoji: "🍓", GrowTime: "3h", HarvestReward: 380, SeedPrice: 50, PreferredSeasons: []string{"Spring", "Summer"}},
+		{Name: "Corn", Emoji: "🌽", GrowTime: "6h", HarvestReward: 680, SeedPrice: 50, PreferredSeasons: []string{"Summer", "Autumn"}},
+		{Name: "Mango", Emoji: "🥭", GrowTime: "12h", HarvestReward: 1220, SeedPrice: 50, PreferredSeasons: []string{"Summer"}},
+		{Name: "Watermelon", Emoji: "🍉", GrowTime: "24h", HarvestReward: 2300, SeedPrice: 50, PreferredSeasons: []string{"Summer"}},
+		{Name: "Apple", Emoji: "🍎", GrowTime: "48h", HarvestReward: 4300, SeedPrice: 50, PreferredSeasons: []string{"Autumn"}},
+		{Name: "Onion", Emoji: "🧅", GrowTime: "72h", HarvestReward: 6200, SeedPrice: 50, PreferredSeasons: []string{"Winter"}},
+		{Name: "Carrot", Emoji: "🥕", GrowTime: "96h", HarvestReward: 8000, SeedPrice: 50, PreferredSeasons: []string{"Spring", "Autumn"}},
+		{Name: "Banana", Emoji: "🍌", GrowTime: "144h", HarvestReward: 11500, SeedPrice: 50, PreferredSeasons: []string{"Spring"}},
+		{Name: "Hot Pepper", Emoji: "🌶️", GrowTime: "192h", HarvestReward: 15000, SeedPrice: 50, PreferredSeasons: []string{"Summer", "Winter"}},
+		{Name: "Avocado", Emoji: "🥑", GrowTime: "240h", HarvestReward: 20000, SeedPrice: 50, PreferredSeasons: []string{"Spring", "Winter"}},
+		{Name: "Grapes", Emoji: "🍇", GrowTime: "360h", HarvestReward: 30000, SeedPrice: 50, PreferredSeasons: []string{"Autumn"}},
+		{Name: "Peach", Emoji: "🍑", GrowTime: "600h", HarvestReward: 50000, SeedPrice: 50, PreferredSeasons: []string{"Spring"}},
+	}
+
+	jsonData, _ := json.MarshalIndent(definitions, "", "   ")
+	return ioutil.WriteFile(path, jsonData, 0644)
+}
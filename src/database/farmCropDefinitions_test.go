@@ -0,0 +1,96 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+func TestDefaultCropsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crops.json")
+
+	definitions, err := loadCropDefinitions(path)
+	test.Validate(t, err, nil, "The default crops file should be created and read")
+
+	crops, err := validateCropDefinitions(definitions)
+	test.Validate(t, err, nil, "The default crops should be valid")
+	test.Validate(t, len(crops), 15, "There should be 15 default crops")
+
+	test.Validate(t, crops[0].Name, "Tomato", "Tomato should be the first crop")
+	test.Validate(t, crops[0].DurationToGrow, 20*time.Minute, "Tomatoes should take 20 minutes to grow")
+	test.Validate(t, crops[0].SeedPrice, 50, "Tomato seeds should cost 50")
+	test.Validate(t, crops[1].PreferredSeasons, "Autumn,Winter", "Potatoes should prefer autumn and winter")
+	test.Validate(t, crops[14].DurationToGrow, 25*24*time.Hour, "Peaches should take 25 days to grow")
+}
+
+func TestValidateCropDefinitions(t *testing.T) {
+
+	valid := CropDefinition{Name: "Tomato", Emoji: "🍅", GrowTime: "20m", HarvestReward: 100, SeedPrice: 50, PreferredSeasons: []string{"summer"}}
+
+	crops, err := validateCropDefinitions([]CropDefinition{valid})
+	test.Validate(t, err, nil, "A valid crop should pass")
+	test.Validate(t, crops[0].PreferredSeasons, "Summer", "The season names should be normalized")
+
+	invalid := map[string]func(d *CropDefinition){
+		"missing name":    func(d *CropDefinition) { d.Name = " " },
+		"missing emoji":   func(d *CropDefinition) { d.Emoji = "" },
+		"bad grow time":   func(d *CropDefinition) { d.GrowTime = "a while" },
+		"short grow time": func(d *CropDefinition) { d.GrowTime = "30s" },
+		"no reward":       func(d *CropDefinition) { d.HarvestReward = 0 },
+		"negative price":  func(d *CropDefinition) { d.SeedPrice = -1 },
+		"negative unlock": func(d *CropDefinition) { d.UnlockHarvests = -1 },
+		"unknown season":  func(d *CropDefinition) { d.PreferredSeasons = []string{"Monsoon"} },
+	}
+
+	for name, change := range invalid {
+		d := valid
+		change(&d)
+		if _, err := validateCropDefinitions([]CropDefinition{d}); err == nil {
+			t.Errorf("A crop with %s should not be valid", name)
+		}
+	}
+
+	duplicate := valid
+	duplicate.Name = "TOMATO"
+	if _, err := validateCropDefinitions([]CropDefinition{valid, duplicate}); err == nil {
+		t.Error("Crops with the same name should not be valid")
+	}
+
+	if _, err := validateCropDefinitions(nil); err == nil {
+		t.Error("An empty crops file should not be valid")
+	}
+}
+
+func TestCheckCropOrder(t *testing.T) {
+
+	stored := []FarmCrop{
+		{Model: Model{ID: 1}, Name: "Tomato"},
+		{Model: Model{ID: 2}, Name: "Potato"},
+		{Model: Model{ID: 3}, Name: "Corn"},
+	}
+
+	crops := func(names ...string) []FarmCrop {
+		var list []FarmCrop
+		for _, name := range names {
+			list = append(list, FarmCrop{Name: name})
+		}
+		return list
+	}
+
+	test.Validate(t, checkCropOrder(stored, crops("Tomato", "Potato", "Corn")), nil, "The same crops should be accepted")
+	test.Validate(t, checkCropOrder(stored, crops("tomato", "Potato", "Corn", "Mango")), nil, "Crops added at the end should be accepted")
+	test.Validate(t, checkCropOrder(stored, crops("Tomato", "Potato")), nil, "Crops removed from the end should be accepted")
+	test.Validate(t, checkCropOrder(nil, crops("Tomato")), nil, "Any order should be accepted when nothing is stored")
+
+	if checkCropOrder(stored, crops("Potato", "Tomato", "Corn")) == nil {
+		t.Error("Reordered crops should not be accepted")
+	}
+	if checkCropOrder(stored, crops("Tomato", "Mango", "Potato", "Corn")) == nil {
+		t.Error("A crop inserted in the middle should not be accepted")
+	}
+	if checkCropOrder(stored, crops("Tomato", "Corn")) == nil {
+		t.Error("A crop removed from the middle should not be accepted")
+	}
+}
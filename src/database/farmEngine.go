@@ -0,0 +1,174 @@
+package database
+
+import (
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+)
+
+/*
+	The farm engine decides how the crops grow, when they need water and when they perish.
+	All the farm code asks the engine instead of calling time.Now() itself,
+	so the rules can be simulated in tests by giving the engine another clock
+*/
+
+// FarmRules are the settings the engine follows
+type FarmRules struct {
+	WaterCooldown        time.Duration // How long until a plot can be watered again
+	WaterGrowthReduction time.Duration // How much growth time is removed when watering
+	PerishAfter          time.Duration // Crops that are not fully grown perish if not watered within this time
+	IgnoreWaterCooldown  bool
+}
+
+type FarmEngine struct {
+	Now   func() time.Time
+	Rules FarmRules
+}
+
+// getFarmEngine returns an engine with the rules from the config
+func getFarmEngine() *FarmEngine {
+	return &FarmEngine{
+		Now: time.Now,
+		Rules: FarmRules{
+			WaterCooldown:        time.Hour * config.CONFIG.Farm.WaterCooldown,
+			WaterGrowthReduction: time.Hour * config.CONFIG.Farm.WaterCropTimeReductionHours,
+			PerishAfter:          time.Hour * config.CONFIG.Farm.CropsPreishAfter,
+			IgnoreWaterCooldown:  config.CONFIG.Debug.IgnoreWaterCooldown,
+		},
+	}
+}
+
+// Plant sets the times for a newly planted crop. It can be watered at once
+func (e *FarmEngine) Plant(fp *FarmPlot) {
+	fp.PlantedAt = e.Now()
+	fp.LastWateredAt = fp.PlantedAt.Add(-e.Rules.WaterCooldown)
+	fp.GrowthBoost = 0
+	fp.Perished = false
+}
+
+// GrowDuration returns how long the crop takes to grow with the season and weather applied
+func (e *FarmEngine) GrowDuration(fp *FarmPlot) time.Duration {
+	if fp.GrowthMultiplier <= 0 {
+		return fp.Crop.DurationToGrow
+	}
+	return time.Duration(float64(fp.Crop.DurationToGrow) / fp.GrowthMultiplier)
+}
+
+// FullyGrownAt returns when the crop is ready to be harvested. Watering makes it earlier
+func (e *FarmEngine) FullyGrownAt(fp *FarmPlot) time.Time {
+	return fp.PlantedAt.Add(e.GrowDuration(fp) - fp.GrowthBoost)
+}
+
+func (e *FarmEngine) HasFullyGrown(fp *FarmPlot) bool {
+	return !e.Now().Before(e.FullyGrownAt(fp))
+}
+
+// Progress returns how far the crop has grown. 0 when planted and 1 when fully grown
+func (e *FarmEngine) Progress(fp *FarmPlot) float64 {
+
+	total := e.FullyGrownAt(fp).Sub(fp.PlantedAt)
+	if total <= 0 {
+		return 1
+	}
+
+	progress := float64(e.Now().Sub(fp.PlantedAt)) / float64(total)
+	if progress > 1 {
+		return 1
+	} else if progress < 0 {
+		return 0
+	}
+	return progress
+}
+
+// GrowthStage returns the current growth stage of the crop
+func (e *FarmEngine) GrowthStage(fp *FarmPlot) GrowthStage {
+
+	if fp.Perished {
+		return StagePerished
+	}
+
+	switch progress := e.Progress(fp); {
+	case progress >= 1:
+		return StageReady
+	case progress < 0.25:
+		return StageSeed
+	case progress < 0.6:
+		return StageSprout
+	default:
+		return StageGrowing
+	}
+}
+
+// CanWater returns true if the plot can be watered. Fully grown and perished crops do not need water
+func (e *FarmEngine) CanWater(fp *FarmPlot) bool {
+	if fp.Perished || e.HasFullyGrown(fp) {
+		return false
+	}
+	return e.Rules.IgnoreWaterCooldown || !e.Now().Before(e.CanWaterAt(fp))
+}
+
+// CanWaterAt returns the time when the plot can be watered again
+func (e *FarmEngine) CanWaterAt(fp *FarmPlot) time.Time {
+	return fp.LastWateredAt.Add(e.Rules.WaterCooldown)
+}
+
+// Water waters the plot, which makes the crop grow faster. Returns false if the plot can't be watered
+func (e *FarmEngine) Water(fp *FarmPlot) bool {
+
+	if !e.CanWater(fp) {
+		return false
+	}
+
+	fp.LastWateredAt = e.Now()
+	fp.GrowthBoost += e.Rules.WaterGrowthReduction
+	return true
+}
+
+// WaterDeadline returns when the crop perishes if it is not watered again.
+// The plot counts as watered while the sprinkler is running
+func (e *FarmEngine) WaterDeadline(fp *FarmPlot, sprinklerUntil time.Time) time.Time {
+
+	lastWatered := fp.LastWateredAt
+	if sprinkled := minTime(sprinklerUntil, e.Now()); sprinkled.After(lastWatered) {
+		lastWatered = sprinkled
+	}
+	return lastWatered.Add(e.Rules.PerishAfter)
+}
+
+// CheckPerished marks the crop as perished if the water deadline passed before it was fully grown
+// Returns true if the crop perished now
+func (e *FarmEngine) CheckPerished(fp *FarmPlot, sprinklerUntil time.Time) bool {
+
+	if fp.Perished {
+		return false
+	}
+
+	deadline := e.WaterDeadline(fp, sprinklerUntil)
+
+	if e.Now().Before(deadline) || !deadline.Before(e.FullyGrownAt(fp)) {
+		return false
+	}
+
+	fp.Perish()
+	return true
+}
+
+// UpdatePlots checks every plot for crops that have perished. The crop info has to be loaded
+// Returns the plots that perished now
+func (e *FarmEngine) UpdatePlots(plots []*FarmPlot, sprinklerUntil time.Time) []*FarmPlot {
+
+	var perished []*FarmPlot
+	for _, plot := range plots {
+		if e.CheckPerished(plot, sprinklerUntil) {
+			perished = append(perished, plot)
+		}
+	}
+	return perished
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+// fakeClock is moved forward by the tests to simulate time passing
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestEngine() (*FarmEngine, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)}
+
+	engine := &FarmEngine{
+		Now: clock.Now,
+		Rules: FarmRules{
+			WaterCooldown:        2 * time.Hour,
+			WaterGrowthReduction: time.Hour,
+			PerishAfter:          24 * time.Hour,
+		},
+	}
+	return engine, clock
+}
+
+func newTestPlot(engine *FarmEngine, growTime time.Duration) *FarmPlot {
+	plot := &FarmPlot{Crop: FarmCrop{Name: "Test", DurationToGrow: growTime}}
+	engine.Plant(plot)
+	return plot
+}
+
+func TestFarmEngineGrowth(t *testing.T) {
+	engine, clock := newTestEngine()
+	plot := newTestPlot(engine, 10*time.Hour)
+
+	test.Validate(t, engine.GrowthStage(plot), StageSeed, "A newly planted crop should be a seed")
+
+	clock.Advance(3 * time.Hour)
+	test.Validate(t, engine.GrowthStage(plot), StageSprout, "The crop should be a sprout after 30% of the time")
+
+	clock.Advance(4 * time.Hour)
+	test.Validate(t, engine.GrowthStage(plot), StageGrowing, "The crop should be growing after 70% of the time")
+	test.Validate(t, engine.HasFullyGrown(plot), false, "The crop should not be fully grown yet")
+
+	clock.Advance(3 * time.Hour)
+	test.Validate(t, engine.GrowthStage(plot), StageReady, "The crop should be ready after the grow time")
+	test.Validate(t, engine.HasFullyGrown(plot), true, "The crop should be fully grown")
+}
+
+func TestFarmEngineGrowthMultiplier(t *testing.T) {
+	engine, clock := newTestEngine()
+	plot := newTestPlot(engine, 10*time.Hour)
+	plot.GrowthMultiplier = 2
+
+	clock.Advance(5 * time.Hour)
+	test.Validate(t, engine.HasFullyGrown(plot), true, "A crop growing twice as fast should be ready after half the time")
+}
+
+func TestFarmEngineWatering(t *testing.T) {
+	engine, clock := newTestEngine()
+	plot := newTestPlot(engine, 10*time.Hour)
+
+	test.Validate(t, engine.Water(plot), true, "A newly planted crop can be watered")
+	test.Validate(t, engine.Water(plot), false, "The plot can't be watered again before the cooldown")
+
+	clock.Advance(2 * time.Hour)
+	test.Validate(t, engine.Water(plot), true, "The plot can be watered after the cooldown")
+
+	// Watered twice, so two hours should have been removed from the grow time
+	test.Validate(t, engine.FullyGrownAt(plot), plot.PlantedAt.Add(8*time.Hour), "Every watering should remove the full reduction from the grow time")
+
+	clock.Advance(6 * time.Hour)
+	test.Validate(t, engine.HasFullyGrown(plot), true, "The watered crop should be ready earlier")
+	test.Validate(t, engine.CanWater(plot), false, "A fully grown crop does not need water")
+}
+
+func TestFarmEnginePerish(t *testing.T) {
+	engine, clock := newTestEngine()
+	plot := newTestPlot(engine, 5*24*time.Hour)
+
+	// Water the crop once a day for three days
+	for day := 0; day < 3; day++ {
+		clock.Advance(20 * time.Hour)
+		engine.CheckPerished(plot, time.Time{})
+		test.Validate(t, plot.Perished, false, "A crop watered every day should not perish")
+		engine.Water(plot)
+	}
+
+	// Forget about it for more than a day
+	clock.Advance(25 * time.Hour)
+	test.Validate(t, engine.CheckPerished(plot, time.Time{}), true, "The crop should perish when it is not watered in time")
+	test.Validate(t, engine.GrowthStage(plot), StagePerished, "The stage of a perished crop should be perished")
+	test.Validate(t, engine.CheckPerished(plot, time.Time{}), false, "A crop can only perish once")
+	test.Validate(t, engine.CanWater(plot), false, "A perished crop can't be watered")
+}
+
+func TestFarmEngineFullyGrownDoesNotPerish(t *testing.T) {
+	engine, clock := newTestEngine()
+	plot := newTestPlot(engine, 12*time.Hour)
+
+	// The crop becomes ready before the deadline and is then left alone for a week
+	for day := 0; day < 7; day++ {
+		clock.Advance(24 * time.Hour)
+		engine.CheckPerished(plot, time.Time{})
+	}
+
+	test.Validate(t, plot.Perished, false, "A crop that was fully grown before the deadline should not perish")
+	test.Validate(t, engine.GrowthStage(plot), StageReady, "The crop should still be ready to harvest")
+}
+
+func TestFarmEngineSprinkler(t *testing.T) {
+	engine, clock := newTestEngine()
+	plot := newTestPlot(engine, 4*24*time.Hour)
+	sprinklerUntil := clock.Now().Add(48 * time.Hour)
+
+	// The sprinkler waters the crop for two days, so the crop lives for one more day after that
+	for hour := 0; hour < 70; hour++ {
+		clock.Advance(time.Hour)
+		test.Validate(t, engine.CheckPerished(plot, sprinklerUntil), false, "The sprinkler should keep the crop watered")
+	}
+
+	clock.Advance(3 * time.Hour)
+	test.Validate(t, engine.CheckPerished(plot, sprinklerUntil), true, "The crop should perish a day after the sprinkler stopped")
+}
+
+func TestFarmEngineUpdatePlots(t *testing.T) {
+	engine, clock := newTestEngine()
+	watered := newTestPlot(engine, 3*24*time.Hour)
+	forgotten := newTestPlot(engine, 3*24*time.Hour)
+
+	// Simulate two days of play where only one of the plots is watered
+	for hour := 0; hour < 48; hour++ {
+		clock.Advance(time.Hour)
+		engine.Water(watered)
+		engine.UpdatePlots([]*FarmPlot{watered, forgotten}, time.Time{})
+	}
+
+	test.Validate(t, watered.Perished, false, "The watered plot should not perish")
+	test.Validate(t, forgotten.Perished, true, "The plot that was never watered should perish")
+	test.Validate(t, len(engine.UpdatePlots([]*FarmPlot{watered, forgotten}, time.Time{})), 0, "Already perished plots should not be returned again")
+}
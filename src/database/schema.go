@@ -14,6 +14,12 @@ type User struct {
 	DiscordID        string `gorm:"uniqueIndex"`
 	Money            uint64
 	LifetimeEarnings uint64
+	StreakFreezes    uint8
+	SongsQueued      int
+	XP               uint64
+	RewardedLevel    uint16 // The highest level the user has been rewarded for
+	Bio              string
+	AccentColor      int   // The color of the profile. 0 for the default
 	Work             Work  `gorm:"foreignKey:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	Daily            Daily `gorm:"foreignKey:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	Farm             Farm  `gorm:"foreignKey:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
@@ -65,18 +71,35 @@ func (u *User) DeductMoney(amount uint64) {
 	u.Money -= amount
 }
 
+// RefundMoney gives back money that was deducted. It is not counted as earnings
+func (u *User) RefundMoney(amount uint64) {
+	u.Money += amount
+}
+
+// AddWinnings gives the user money won from another user or the house. It is not counted as earnings
+func (u *User) AddWinnings(amount uint64) {
+	u.Money += amount
+}
+
 func (u *User) CanAfford(number uint64) bool {
 	return u.Money >= number
 }
 
 func (u *User) CreateProfileEmbeds(du *discordgo.User, work *Work, daily *Daily, embeds *[]*discordgo.MessageEmbed) {
 
+	stats := u.QueryProfileStats(work, daily)
+
+	description := u.LevelDescription()
+	if len(u.Bio) > 0 {
+		description = fmt.Sprintf("*%s*\n\n%s", u.Bio, description)
+	}
+
 	*embeds = append(*embeds, &discordgo.MessageEmbed{
 		Type:        discordgo.EmbedTypeRich,
-		Color:       config.CONFIG.Colors.Neutral,
+		Color:       u.ProfileColor(),
 		Title:       fmt.Sprintf("%s#%s's profile", du.Username, du.Discriminator),
-		Description: "",
-		Fields:      u.createProfileFields(work, daily),
+		Description: description,
+		Fields:      u.createProfileFields(work, daily, &stats),
 		Thumbnail: &discordgo.MessageEmbedThumbnail{
 			URL: fmt.Sprintf("%s#%s", du.AvatarURL("256"), du.ID),
 		},
@@ -103,6 +126,17 @@ func (u *User) CreateProfileComponents(work *Work, daily *Daily) []discordgo.Mes
 		CustomID: "PW", // 'PW' is code for 'Profile Work'
 	})
 
+	// Disabled when the user owns the max amount of streak freezes
+	components = append(components, &discordgo.Button{
+		Label:    fmt.Sprintf("Buy Streak Freeze (%s)", utils.HumanReadableNumber(config.CONFIG.StreakFreeze.Price)),
+		Style:    3, // Green color style
+		Disabled: u.HasMaxStreakFreezes(),
+		Emoji: discordgo.ComponentEmoji{
+			Name: config.CONFIG.Emojis.ComponentEmojiNames.Freeze,
+		},
+		CustomID: "BSF", // 'BSF' is code for 'Buy Streak Freeze'
+	})
+
 	components = append(components, &discordgo.Button{
 		Label:    "",
 		Style:    1, // Default purple
@@ -121,7 +155,7 @@ func (u *User) CreateProfileComponents(work *Work, daily *Daily) []discordgo.Mes
 }
 
 // CreateProfileFields generates the profile fields for message
-func (u *User) createProfileFields(work *Work, daily *Daily) []*discordgo.MessageEmbedField {
+func (u *User) createProfileFields(work *Work, daily *Daily, stats *ProfileStats) []*discordgo.MessageEmbedField {
 	// The statuses on the cooldown's
 	workStatus := config.CONFIG.Emojis.Success
 	if !work.CanDoWork() {
@@ -139,6 +173,16 @@ func (u *User) createProfileFields(work *Work, daily *Daily) []*discordgo.Messag
 			Value:  fmt.Sprintf("%s %s", config.CONFIG.Emojis.Economy, u.PrettyPrintMoney()),
 			Inline: true,
 		},
+		{
+			Name:   "Net Worth",
+			Value:  fmt.Sprintf("%s %s", config.CONFIG.Emojis.Economy, utils.HumanReadableNumber(stats.NetWorth)),
+			Inline: true,
+		},
+		{
+			Name:   "Rank",
+			Value:  fmt.Sprintf("#%d by earnings", stats.Rank),
+			Inline: true,
+		},
 		{
 			Name:   "Daily",
 			Value:  dailyStatus,
@@ -149,6 +193,33 @@ func (u *User) createProfileFields(work *Work, daily *Daily) []*discordgo.Messag
 			Value:  workStatus,
 			Inline: true,
 		},
+		{
+			Name:   fmt.Sprintf("Streak Freezes %s", config.CONFIG.Emojis.StreakFreeze),
+			Value:  fmt.Sprintf("%d/%d", u.StreakFreezes, config.CONFIG.StreakFreeze.MaxOwned),
+			Inline: true,
+		},
+		{
+			Name:   "Streaks",
+			Value:  fmt.Sprintf("Work: %d\nDaily: %d", stats.WorkStreak, stats.DailyStreak),
+			Inline: true,
+		},
+		{
+			Name:   fmt.Sprintf("Farm %s", config.CONFIG.Emojis.GrowingStage),
+			Value:  stats.FarmSummary(),
+			Inline: true,
+		},
+		{
+			Name:   "Achievements",
+			Value:  fmt.Sprintf("%d/%d unlocked", stats.Achievements, stats.TotalAchievements),
+			Inline: true,
+		},
+	}
+
+	if badges := u.Badges(); len(badges) > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  "Badges",
+			Value: badges,
+		})
 	}
 
 	return fields
@@ -0,0 +1,179 @@
+package database
+
+import (
+	"strings"
+)
+
+/*
+	Achievements reward milestones. Each achievement is unlocked once, when its predicate over the user's stats is true,
+	and gives a one-time reward and a badge shown on the profile.
+	The ID of an achievement is saved in the database, so it must never change
+*/
+
+type UserAchievement struct {
+	Model
+	UserID        uint `gorm:"index"`
+	AchievementID string
+}
+
+func (UserAchievement) TableName() string {
+	return "userAchievements"
+}
+
+// AchievementStats are the stats the achievements are unlocked by
+type AchievementStats struct {
+	LifetimeEarnings uint64
+	Shifts           int
+	WorkStreak       uint16
+	DailyStreak      uint16
+	CropsHarvested   int
+	SongsQueued      int
+	Level            int
+}
+
+type Achievement struct {
+	ID          string
+	Name        string
+	Description string
+	Badge       string
+	Reward      int
+	unlocked    func(stats *AchievementStats) bool
+}
+
+// The achievements in the order they are shown
+var achievements = []Achievement{
+	{
+		ID: "first_shift", Name: "First Paycheck", Description: "Work your first shift", Badge: ":briefcase:", Reward: 100,
+		unlocked: func(stats *AchievementStats) bool { return stats.Shifts >= 1 },
+	},
+	{
+		ID: "shifts_100", Name: "Hard Worker", Description: "Work 100 shifts", Badge: ":tools:", Reward: 2500,
+		unlocked: func(stats *AchievementStats) bool { return stats.Shifts >= 100 },
+	},
+	{
+		ID: "work_streak_7", Name: "Dedicated", Description: "Reach a work streak of 7", Badge: ":fire:", Reward: 1000,
+		unlocked: func(stats *AchievementStats) bool { return stats.WorkStreak >= 7 },
+	},
+	{
+		ID: "daily_streak_7", Name: "Creature of Habit", Description: "Reach a daily streak of 7", Badge: ":calendar:", Reward: 1000,
+		unlocked: func(stats *AchievementStats) bool { return stats.DailyStreak >= 7 },
+	},
+	{
+		ID: "daily_streak_30", Name: "Unbreakable", Description: "Reach a daily streak of 30", Badge: ":gem:", Reward: 5000,
+		unlocked: func(stats *AchievementStats) bool { return stats.DailyStreak >= 30 },
+	},
+	{
+		ID: "earnings_10k", Name: "Pocket Money", Description: "Earn 10,000 in total", Badge: ":coin:", Reward: 500,
+		unlocked: func(stats *AchievementStats) bool { return stats.LifetimeEarnings >= 10_000 },
+	},
+	{
+		ID: "earnings_100k", Name: "Wealthy", Description: "Earn 100,000 in total", Badge: ":moneybag:", Reward: 2500,
+		unlocked: func(stats *AchievementStats) bool { return stats.LifetimeEarnings >= 100_000 },
+	},
+	{
+		ID: "earnings_1m", Name: "Millionaire", Description: "Earn 1,000,000 in total", Badge: ":crown:", Reward: 10000,
+		unlocked: func(stats *AchievementStats) bool { return stats.LifetimeEarnings >= 1_000_000 },
+	},
+	{
+		ID: "level_10", Name: "Rising Star", Description: "Reach level 10", Badge: ":star:", Reward: 1000,
+		unlocked: func(stats *AchievementStats) bool { return stats.Level >= 10 },
+	},
+	{
+		ID: "harvests_1", Name: "Green Thumb", Description: "Harvest your first crop", Badge: ":seedling:", Reward: 100,
+		unlocked: func(stats *AchievementStats) bool { return stats.CropsHarvested >= 1 },
+	},
+	{
+		ID: "harvests_100", Name: "Master Farmer", Description: "Harvest 100 crops", Badge: ":tractor:", Reward: 2500,
+		unlocked: func(stats *AchievementStats) bool { return stats.CropsHarvested >= 100 },
+	},
+	{
+		ID: "songs_1", Name: "DJ", Description: "Queue your first song", Badge: ":musical_note:", Reward: 50,
+		unlocked: func(stats *AchievementStats) bool { return stats.SongsQueued >= 1 },
+	},
+	{
+		ID: "songs_100", Name: "Music Lover", Description: "Queue 100 songs", Badge: ":headphones:", Reward: 1000,
+		unlocked: func(stats *AchievementStats) bool { return stats.SongsQueued >= 100 },
+	},
+}
+
+// GetAchievements returns every achievement
+func GetAchievements() []Achievement {
+	return achievements
+}
+
+// QueryAchievementStats queries the stats the achievements are unlocked by
+func (u *User) QueryAchievementStats() AchievementStats {
+
+	var work Work
+	DB.Raw("SELECT * FROM userWorkData WHERE userWorkData.ID = ?", u.ID).First(&work)
+
+	var daily Daily
+	daily.GetDailyInfo(u)
+
+	var cropsHarvested int
+	DB.Raw("SELECT total_harvests FROM userFarms WHERE userFarms.ID = ?", u.ID).Scan(&cropsHarvested)
+
+	return AchievementStats{
+		LifetimeEarnings: u.LifetimeEarnings,
+		Shifts:           work.Shifts,
+		WorkStreak:       work.ConsecutiveStreaks,
+		DailyStreak:      daily.ConsecutiveStreaks,
+		CropsHarvested:   cropsHarvested,
+		SongsQueued:      u.SongsQueued,
+		Level:            u.GetLevel(),
+	}
+}
+
+// QueryUnlockedAchievements returns the IDs of the achievements the user has unlocked
+func (u *User) QueryUnlockedAchievements() map[string]bool {
+
+	var unlocked []UserAchievement
+	DB.Where("user_id = ?", u.ID).Find(&unlocked)
+
+	ids := map[string]bool{}
+	for _, a := range unlocked {
+		ids[a.AchievementID] = true
+	}
+	return ids
+}
+
+// CheckAchievements unlocks the achievements the user has reached and gives the rewards
+// Returns the newly unlocked achievements. Remember to save the user
+func (u *User) CheckAchievements() []Achievement {
+
+	stats := u.QueryAchievementStats()
+	newlyUnlocked := newlyUnlockedAchievements(&stats, u.QueryUnlockedAchievements())
+
+	for _, a := range newlyUnlocked {
+		DB.Create(&UserAchievement{UserID: u.ID, AchievementID: a.ID})
+		u.AddMoney(uint64(a.Reward))
+	}
+
+	return newlyUnlocked
+}
+
+// newlyUnlockedAchievements returns the achievements the stats reach that are not unlocked yet
+func newlyUnlockedAchievements(stats *AchievementStats, unlocked map[string]bool) []Achievement {
+
+	var newlyUnlocked []Achievement
+	for _, a := range achievements {
+		if !unlocked[a.ID] && a.unlocked(stats) {
+			newlyUnlocked = append(newlyUnlocked, a)
+		}
+	}
+	return newlyUnlocked
+}
+
+// Badges returns the badges of the achievements the user has unlocked. Empty if none
+func (u *User) Badges() string {
+
+	unlocked := u.QueryUnlockedAchievements()
+
+	var badges []string
+	for _, a := range achievements {
+		if unlocked[a.ID] {
+			badges = append(badges, a.Badge)
+		}
+	}
+	return strings.Join(badges, " ")
+}
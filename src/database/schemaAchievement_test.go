@@ -0,0 +1,31 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+func TestNewlyUnlockedAchievements(t *testing.T) {
+
+	stats := AchievementStats{}
+	test.Validate(t, len(newlyUnlockedAchievements(&stats, map[string]bool{})), 0, "Nothing should be unlocked without any stats")
+
+	stats = AchievementStats{Shifts: 1, LifetimeEarnings: 150_000}
+	unlocked := newlyUnlockedAchievements(&stats, map[string]bool{})
+	test.Validate(t, len(unlocked), 3, "The first shift and two earning achievements should be unlocked")
+	test.Validate(t, unlocked[0].ID, "first_shift", "The achievements should be in the order they are shown")
+
+	unlocked = newlyUnlockedAchievements(&stats, map[string]bool{"first_shift": true, "earnings_10k": true})
+	test.Validate(t, len(unlocked), 1, "Unlocked achievements should not be unlocked again")
+	test.Validate(t, unlocked[0].ID, "earnings_100k", "Only the achievement not unlocked yet should be returned")
+}
+
+func TestAchievementIDsAreUnique(t *testing.T) {
+
+	ids := map[string]bool{}
+	for _, a := range GetAchievements() {
+		test.Validate(t, ids[a.ID], false, "The achievement ID '"+a.ID+"' should be unique")
+		ids[a.ID] = true
+	}
+}
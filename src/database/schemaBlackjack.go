@@ -0,0 +1,138 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/CarlFlo/malm"
+	"gorm.io/gorm"
+)
+
+/*
+	A blackjack hand holds the bet while the game is played. The game itself is only kept in memory,
+	so the bets of the hands that did not end before the bot was stopped are given back when it starts again.
+	Each user can only have one open hand
+*/
+
+type BlackjackHand struct {
+	Model
+	UserID uint `gorm:"uniqueIndex"`
+	Bet    uint64
+}
+
+func (BlackjackHand) TableName() string {
+	return "blackjackHands"
+}
+
+var errBlackjackHandEnded = errors.New("the blackjack hand has already ended")
+
+// Start takes the bet from the user and creates the hand
+// Returns false if the user can't afford the bet or already has a hand
+func (bh *BlackjackHand) Start(user *User, bet uint64) bool {
+
+	bh.UserID = user.ID
+	bh.Bet = bet
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+
+		// The user is queried again, so the money is not spent twice
+		if err := tx.First(user, user.ID).Error; err != nil {
+			return err
+		}
+
+		if !user.CanAfford(bet) {
+			return errors.New("the user can't afford the bet")
+		}
+
+		if err := tx.Create(bh).Error; err != nil {
+			return err
+		}
+
+		user.DeductMoney(bet)
+		return tx.Save(user).Error
+	})
+
+	return err == nil
+}
+
+// DoubleDown takes the bet from the user again
+// Returns false if the user can't afford it or the hand has ended
+func (bh *BlackjackHand) DoubleDown(user *User) bool {
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+
+		if err := tx.First(user, user.ID).Error; err != nil {
+			return err
+		}
+
+		if !user.CanAfford(bh.Bet) {
+			return errors.New("the user can't afford to double down")
+		}
+
+		result := tx.Model(&BlackjackHand{}).Where("id = ?", bh.ID).Update("bet", bh.Bet*2)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return errBlackjackHandEnded
+		}
+
+		user.DeductMoney(bh.Bet)
+		return tx.Save(user).Error
+	})
+
+	if err != nil {
+		return false
+	}
+	bh.Bet *= 2
+	return true
+}
+
+// End deletes the hand, so the bet can be settled by the game
+// Returns false if the hand has already ended
+func (bh *BlackjackHand) End() bool {
+
+	result := DB.Delete(&BlackjackHand{}, bh.ID)
+	return result.Error == nil && result.RowsAffected == 1
+}
+
+// Refund ends the hand and gives the bet back
+// Returns false if the hand has already ended
+func (bh *BlackjackHand) Refund() bool {
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+
+		var stored BlackjackHand
+		if err := tx.First(&stored, bh.ID).Error; err != nil {
+			return errBlackjackHandEnded
+		}
+
+		result := tx.Delete(&BlackjackHand{}, bh.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return errBlackjackHandEnded
+		}
+
+		return refundStake(tx, stored.UserID, stored.Bet)
+	})
+
+	return err == nil
+}
+
+// RefundOpenBlackjackHands gives back the bets of the hands that did not end before the bot was stopped
+func RefundOpenBlackjackHands() {
+
+	var hands []BlackjackHand
+	DB.Find(&hands)
+
+	for _, bh := range hands {
+		if !bh.Refund() {
+			malm.Error("Could not refund the blackjack hand %d", bh.ID)
+		}
+	}
+
+	if len(hands) > 0 {
+		malm.Info("Refunded %d unfinished blackjack hands", len(hands))
+	}
+}
@@ -15,6 +15,8 @@ type Daily struct {
 	LastDailyAt        time.Time
 	ConsecutiveStreaks uint16
 	Streak             uint16
+
+	UsedStreakFreezes int `gorm:"-"` // Set when streak freezes kept the streak
 }
 
 func (Daily) TableName() string {
@@ -49,7 +51,7 @@ func (d *Daily) GetDailyInfo(u *User) {
 func (d *Daily) DoDaily(user *User) (bool, string, string, string, string, string) {
 
 	// Resets streaks down to 0 if the user failed their streak.
-	d.checkStreak()
+	d.checkStreak(user)
 
 	// Can't do their daily
 	if !d.CanDoDaily() {
@@ -132,10 +134,15 @@ func (d *Daily) CanDoDailyAt() string {
 	return fmt.Sprintf("<t:%d:R>", nextTime)
 }
 
-// checkStreak - Checks the streak for the work object
-// Resets it down to 0 if the user failed their streak. i.e. Waited too long since the last work
-func (d *Daily) checkStreak() {
-	if time.Since(d.LastDailyAt).Hours() > float64(config.CONFIG.Daily.StreakResetHours) {
+// checkStreak - Checks the streak for the daily object
+// Resets it down to 0 if the user failed their streak. i.e. Waited too long since the last daily
+// The user's streak freezes are used to keep the streak if they can cover the missed time
+func (d *Daily) checkStreak(user *User) {
+	if missed := missedStreakWindows(time.Since(d.LastDailyAt), config.CONFIG.Daily.StreakResetHours); missed > 0 {
+		if d.ConsecutiveStreaks > 0 && d.CanDoDaily() && user.useStreakFreezes(missed, "daily") {
+			d.UsedStreakFreezes = missed
+			return
+		}
 		d.ConsecutiveStreaks = 0
 		d.Streak = 0
 	}
@@ -0,0 +1,197 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/CarlFlo/malm"
+	"gorm.io/gorm"
+)
+
+/*
+	A duel holds the stakes of both users until it ends. The stake of the challenger is taken when the duel is created,
+	and the stake of the opponent when they accept. Each step moves the money in one transaction,
+	and ending the duel deletes it, so the stakes can't be paid out twice
+*/
+
+type Duel struct {
+	Model
+	ChallengerID uint `gorm:"index"`
+	OpponentID   uint `gorm:"index"`
+	Stake        uint64
+	Accepted     bool
+}
+
+func (Duel) TableName() string {
+	return "duels"
+}
+
+var errDuelEnded = errors.New("the duel has already ended")
+
+// CreateDuel takes the stake from the challenger and creates the duel
+// Returns false if the challenger can't afford the stake
+func (d *Duel) CreateDuel(challengerID uint, opponentID uint, stake uint64) bool {
+
+	d.ChallengerID = challengerID
+	d.OpponentID = opponentID
+	d.Stake = stake
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+
+		var challenger User
+		if err := tx.First(&challenger, challengerID).Error; err != nil {
+			return err
+		}
+
+		if !challenger.CanAfford(stake) {
+			return errors.New("the challenger can't afford the stake")
+		}
+
+		challenger.DeductMoney(stake)
+		if err := tx.Save(&challenger).Error; err != nil {
+			return err
+		}
+		return tx.Create(d).Error
+	})
+
+	return err == nil
+}
+
+// Accept takes the stake from the opponent
+// Returns false if the opponent can't afford the stake or the duel has ended
+func (d *Duel) Accept() bool {
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+
+		var opponent User
+		if err := tx.First(&opponent, d.OpponentID).Error; err != nil {
+			return err
+		}
+
+		if !opponent.CanAfford(d.Stake) {
+			return errors.New("the opponent can't afford the stake")
+		}
+
+		result := tx.Model(d).Where("accepted = ?", false).Update("accepted", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return errDuelEnded
+		}
+
+		opponent.DeductMoney(d.Stake)
+		return tx.Save(&opponent).Error
+	})
+
+	if err != nil {
+		return false
+	}
+	d.Accepted = true
+	return true
+}
+
+// Payout ends the duel and gives both stakes to the winner. The result is recorded in the money history of both users
+// Returns false if the duel has already ended
+func (d *Duel) Payout(winnerID uint) bool {
+
+	loserID := d.ChallengerID
+	if winnerID == d.ChallengerID {
+		loserID = d.OpponentID
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+
+		if _, err := d.end(tx); err != nil {
+			return err
+		}
+
+		var winner User
+		if err := tx.First(&winner, winnerID).Error; err != nil {
+			return err
+		}
+
+		winner.RefundMoney(d.Stake)
+		winner.AddWinnings(d.Stake)
+		if err := tx.Save(&winner).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&MoneyHistory{UserID: winnerID, Amount: int64(d.Stake), Reason: "duel"}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&MoneyHistory{UserID: loserID, Amount: -int64(d.Stake), Reason: "duel"}).Error
+	})
+
+	return err == nil
+}
+
+// Refund ends the duel and gives the stakes back
+// Returns false if the duel has already ended
+func (d *Duel) Refund() bool {
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+
+		// The duel might have been accepted since it was loaded
+		stored, err := d.end(tx)
+		if err != nil {
+			return err
+		}
+
+		if err := refundStake(tx, stored.ChallengerID, stored.Stake); err != nil {
+			return err
+		}
+
+		if stored.Accepted {
+			return refundStake(tx, stored.OpponentID, stored.Stake)
+		}
+		return nil
+	})
+
+	return err == nil
+}
+
+// end deletes the duel and returns it as it was stored. Fails if it already has ended
+func (d *Duel) end(tx *gorm.DB) (Duel, error) {
+
+	var stored Duel
+	if err := tx.First(&stored, d.ID).Error; err != nil {
+		return stored, errDuelEnded
+	}
+
+	result := tx.Delete(&Duel{}, d.ID)
+	if result.Error != nil {
+		return stored, result.Error
+	}
+	if result.RowsAffected != 1 {
+		return stored, errDuelEnded
+	}
+	return stored, nil
+}
+
+func refundStake(tx *gorm.DB, userID uint, stake uint64) error {
+
+	var user User
+	if err := tx.First(&user, userID).Error; err != nil {
+		return err
+	}
+
+	user.RefundMoney(stake)
+	return tx.Save(&user).Error
+}
+
+// RefundOpenDuels gives back the stakes of the duels that did not end before the bot was stopped
+func RefundOpenDuels() {
+
+	var duels []Duel
+	DB.Find(&duels)
+
+	for _, d := range duels {
+		if !d.Refund() {
+			malm.Error("Could not refund the duel %d", d.ID)
+		}
+	}
+
+	if len(duels) > 0 {
+		malm.Info("Refunded %d unfinished duels", len(duels))
+	}
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"fmt"
 	"math"
+	"math/rand"
 	"strings"
 	"time"
 
@@ -16,11 +17,17 @@ type Farm struct {
 	Model
 	Plots                   []*FarmPlot
 	OwnedPlots              uint8
-	LastWateredAt           time.Time // Last time the user watered the farm plots
-	HighestPlantedCropIndex uint8
+	HighestPlantedCropIndex uint8     // The newest crop the user has unlocked
+	PrestigeLevel           uint8     // Each level gives a permanent yield bonus
+	Fertilized              bool      // The next harvest yields more
+	SprinklerUntil          time.Time // The plots count as watered until this time
+	HasScarecrow            bool      // Protects the crops from crows
+	TotalHarvests           int       // Every crop ever harvested. Not reset by prestige
 
 	PlotsChanged    bool `gorm:"-"` // Ignored by the database
 	HarvestEarnings int  `gorm:"-"` // If 0 then no earnings
+	UnlockedNewCrop bool `gorm:"-"` // Set when a harvest unlocked the next crop
+	IsGuildFarm     bool `gorm:"-"` // The farm is shared by the members of a guild
 }
 
 /*
@@ -28,8 +35,8 @@ type Farm struct {
 	The farm plot keeps track of which farm it belongs to
 
 	Limit the crops the user can plant
-	They unlock better crops by planting the basic first
-	Planting crop ID 1 will then unlock ID 2 and so on
+	They unlock better crops by harvesting the basic first
+	Harvesting crop ID 1 enough times will then unlock ID 2 and so on
 */
 
 func (Farm) TableName() string {
@@ -39,15 +46,9 @@ func (Farm) TableName() string {
 func (f *Farm) BeforeCreate(tx *gorm.DB) error {
 
 	f.HighestPlantedCropIndex = 1
-	f.ResetLastWatered()
 	return nil
 }
 
-// ResetLastWatered updates last watered to so that the user can water their plats again
-func (f *Farm) ResetLastWatered() {
-	f.LastWateredAt = time.Now().Add(time.Hour * config.CONFIG.Farm.WaterCooldown * -1)
-}
-
 // Saves the data to the database
 func (f *Farm) Save() {
 
@@ -58,7 +59,7 @@ func (f *Farm) Save() {
 		}
 	}
 
-	DB.Save(&f)
+	DB.Omit("Plots").Save(f)
 }
 
 // Queries the database for the farm data with the given user object.
@@ -86,45 +87,27 @@ func (f *Farm) QueryFarmPlots() {
 // Returns true if any crop perished
 func (f *Farm) Peek() bool {
 
-	anyCropsPerished := false
+	anyCropsPerished := len(f.updatePlots()) > 0
 
-	// Checks if the user has watered their crops in the last x hours
-	if float64(config.CONFIG.Farm.CropsPreishAfter) > time.Since(f.LastWateredAt).Hours() {
-		return anyCropsPerished
-	}
+	f.Save()
+	return anyCropsPerished
+}
 
-	// User has not watered in the last n hours. Thus missing the set deadline
-	// Crops not fully grown (ready to be harvested) will perish
-	for _, plot := range f.Plots {
+// updatePlots lets the farm engine check every plot for crops that have perished
+// Returns the plots that perished now
+func (f *Farm) updatePlots() []*FarmPlot {
 
+	for _, plot := range f.Plots {
 		plot.QueryCropInfo()
+	}
 
-		if plot.HasFullyGrown() {
-			// Crop has fully grown so we need to check if the user didn't
-			// just wait the whole growth duration without watering it
-
-			crop := &plot.Crop
-			// Calc the time when the crop will be fully grown
-			fullyGrownAt := plot.PlantedAt.Add(crop.DurationToGrow)
-
-			// Calc the time when the crop would have had to be watered
-			waterDeadline := fullyGrownAt.Add(time.Hour * config.CONFIG.Farm.CropsPreishAfter * -1)
-
-			// If the last time the plant was watered is after the deadline
-			// Then the crop is ok, lse it will perish
-			if f.LastWateredAt.After(waterDeadline) {
-				// The crop is ok
-				continue
-			}
-		}
-
+	// Each plot has its own water deadline. Crops not fully grown will perish if it is missed
+	// The sprinkler keeps the plots watered while it is running
+	perished := getFarmEngine().UpdatePlots(f.Plots, f.SprinklerUntil)
+	if len(perished) > 0 {
 		f.PlotsChanged = true
-		anyCropsPerished = true
-		plot.Perish()
 	}
-
-	f.Save()
-	return anyCropsPerished
+	return perished
 }
 
 func (f *Farm) UpdateInteractionOverview(discordUser *discordgo.User, me *discordgo.MessageEdit) {
@@ -135,8 +118,7 @@ func (f *Farm) UpdateInteractionOverview(discordUser *discordgo.User, me *discor
 	user.QueryUserByDiscordID(discordUser.ID)
 
 	// Handle message components
-	f.overviewCreateButtons(&me.Components, &user)
-	f.overviewCreateCropMenu(&me.Components, &user)
+	f.overviewCreateComponents(&me.Components, &user)
 }
 
 // CreateFarmOverview creates the message that will be sent to the user
@@ -151,8 +133,15 @@ func (f *Farm) CreateFarmOverview(msg *discordgo.MessageSend, m *discordgo.Messa
 	f.overviewCreateEmbed(&msg.Embeds, m.Author)
 
 	// Handle message components
-	f.overviewCreateButtons(&msg.Components, user)
-	f.overviewCreateCropMenu(&msg.Components, user)
+	f.overviewCreateComponents(&msg.Components, user)
+}
+
+// overviewCreateComponents creates the buttons and menus. The user is the one paying for what is bought
+func (f *Farm) overviewCreateComponents(msgCompondents *[]discordgo.MessageComponent, user *User) {
+	f.overviewCreateButtons(msgCompondents, user)
+	f.overviewCreateCropMenu(msgCompondents, user)
+	f.overviewCreatePlotMenu(msgCompondents)
+	f.overviewCreateUpgradeMenu(msgCompondents, user)
 }
 
 func (f *Farm) overviewCreateEmbed(embeds *[]*discordgo.MessageEmbed, discordUser *discordgo.User) {
@@ -174,40 +163,107 @@ func (f *Farm) overviewCreateEmbed(embeds *[]*discordgo.MessageEmbed, discordUse
 
 func (f *Farm) overviewCreateCropMenu(msgCompondents *[]discordgo.MessageComponent, user *User) {
 
-	// User can't afford to plant so no need to create the menu
-	if !user.CanAfford(uint64(config.CONFIG.Farm.CropSeedPrice)) {
+	if !f.HasFreePlot() {
 		return
-	} else if !f.HasFreePlot() {
+	}
+
+	// User can't afford any of the seeds so no need to create the menu
+	options := f.createCropOptions(user)
+	if len(options) == 0 {
+		return
+	}
+
+	menuComponent := []discordgo.MessageComponent{
+		&discordgo.SelectMenu{
+			CustomID:    f.customID("FPC"), // 'FPC' is code for 'Farm Plant Crop'
+			Placeholder: "Select a crop to plant",
+			MaxValues:   1,
+			Options:     options,
+		},
+	}
+
+	*msgCompondents = append(*msgCompondents, discordgo.ActionsRow{
+		Components: menuComponent,
+	})
+
+}
+
+// overviewCreatePlotMenu creates the menu for harvesting or uprooting a single plot
+func (f *Farm) overviewCreatePlotMenu(msgCompondents *[]discordgo.MessageComponent) {
+
+	if !f.HasPlantedPlots() {
 		return
 	}
 
 	menuComponent := []discordgo.MessageComponent{
 		&discordgo.SelectMenu{
-			CustomID:    "FPC", // 'FPC' is code for 'Farm Plant Crop'
-			Placeholder: fmt.Sprintf("Select a crop to plant (Cost %s %s)", utils.HumanReadableNumber(config.CONFIG.Farm.CropSeedPrice), config.CONFIG.Economy.Name),
+			CustomID:    f.customID("FPA"), // 'FPA' is code for 'Farm Plot Action'
+			Placeholder: "Select a plot to harvest or uproot",
 			MaxValues:   1,
-			Options:     f.createCropOptions(),
+			Options:     f.createPlotOptions(),
 		},
 	}
 
 	*msgCompondents = append(*msgCompondents, discordgo.ActionsRow{
 		Components: menuComponent,
 	})
+}
+
+// The value of each option is the action and the plot ID. e.g. 'harvest:12'
+func (f *Farm) createPlotOptions() []discordgo.SelectMenuOption {
+
+	options := []discordgo.SelectMenuOption{}
+
+	for i, plot := range f.Plots {
+
+		plot.QueryCropInfo()
+
+		action := "uproot"
+		label := fmt.Sprintf("%d) Uproot %s (%s)", i+1, plot.Crop.Name, plot.GrowthStage())
 
+		switch plot.GrowthStage() {
+		case StageReady:
+			action = "harvest"
+			label = fmt.Sprintf("%d) Harvest %s | %s %s", i+1, plot.Crop.Name, utils.HumanReadableNumber(plot.Crop.HarvestReward), config.CONFIG.Economy.Name)
+		case StagePerished:
+			action = "clear"
+			label = fmt.Sprintf("%d) Clear the perished %s", i+1, plot.Crop.Name)
+		}
+
+		options = append(options, discordgo.SelectMenuOption{
+			Label: label,
+			Value: fmt.Sprintf("%s:%d", action, plot.ID),
+		})
+	}
+
+	return options
 }
 
-func (f *Farm) createCropOptions() []discordgo.SelectMenuOption {
+// createCropOptions returns the unlocked crops the user can afford to plant
+func (f *Farm) createCropOptions(user *User) []discordgo.SelectMenuOption {
 
 	options := []discordgo.SelectMenuOption{}
 
 	var crops []FarmCrop
 	DB.Where("id <= ?", f.HighestPlantedCropIndex).Order("id desc").Limit(int(f.HighestPlantedCropIndex)).Find(&crops)
 
+	season := SeasonAt(getFarmEngine().Now())
+
 	for _, crop := range crops {
 
+		if !user.CanAfford(uint64(crop.SeedPrice)) {
+			continue
+		}
+
+		description := fmt.Sprintf("Seeds cost %s | Preferred seasons: %s", utils.HumanReadableNumber(crop.SeedPrice), crop.PreferredSeasonsEmojis())
+		if crop.IsInSeason(season) {
+			description = fmt.Sprintf("Seeds cost %s | In season! Grows faster and earns %.1fx", utils.HumanReadableNumber(crop.SeedPrice), config.CONFIG.Farm.InSeasonRewardMultiplier)
+		}
+
 		options = append(options, discordgo.SelectMenuOption{
-			Label: fmt.Sprintf("%s | %s | %s %s", crop.Name, crop.GetDuration(), utils.HumanReadableNumber(crop.HarvestReward), config.CONFIG.Economy.Name),
-			Value: crop.Name,
+			Label:       fmt.Sprintf("%s | %s | %s %s", crop.Name, crop.GetDuration(), utils.HumanReadableNumber(crop.HarvestReward), config.CONFIG.Economy.Name),
+			Description: description,
+			Value:       crop.Name,
 			Emoji: discordgo.ComponentEmoji{
 				Name: crop.Emoji,
 			},
@@ -225,12 +281,12 @@ func (f *Farm) overviewCreateButtons(msgCompondents *[]discordgo.MessageComponen
 	btnComponents = append(btnComponents, &discordgo.Button{
 		Label:    "Harvest",
 		Disabled: !(f.CanHarvest() && f.HasPlantedPlots()),
-		CustomID: "FH", // 'FH' is code for 'Farm Harvest'
+		CustomID: f.customID("FH"), // 'FH' is code for 'Farm Harvest'
 	})
 	btnComponents = append(btnComponents, &discordgo.Button{
 		Label:    "Water",
 		Disabled: !(f.CanWater() && f.HasPlantedPlots()), // Disable if nothing is planted
-		CustomID: "FW",                                   // 'FW' is code for 'Farm Water'
+		CustomID: f.customID("FW"),                       // 'FW' is code for 'Farm Water'
 	})
 
 	// For buying an additional plot (only if they haven't reached the limit)
@@ -248,7 +304,7 @@ func (f *Farm) overviewCreateButtons(msgCompondents *[]discordgo.MessageComponen
 			Emoji: discordgo.ComponentEmoji{
 				Name: config.CONFIG.Emojis.ComponentEmojiNames.MoneyBag,
 			},
-			CustomID: "BFP", // 'BFP' is code for 'Buy Farm Plot'
+			CustomID: f.customID("BFP"), // 'BFP' is code for 'Buy Farm Plot'
 		})
 	}
 
@@ -259,7 +315,7 @@ func (f *Farm) overviewCreateButtons(msgCompondents *[]discordgo.MessageComponen
 		Emoji: discordgo.ComponentEmoji{
 			Name: config.CONFIG.Emojis.ComponentEmojiNames.Help,
 		},
-		CustomID: "FHELP", // 'FHELP' is code for 'Farm Help'; Provies commands and information regarding farming
+		CustomID: f.customID("FHELP"), // 'FHELP' is code for 'Farm Help'; Provies commands and information regarding farming
 	})
 
 	*msgCompondents = append(*msgCompondents, discordgo.ActionsRow{
@@ -288,17 +344,42 @@ func (f *Farm) HasPlantedPlots() bool {
 	return len(f.Plots) > 0
 }
 
-// Returns true if the user can water their crops
+// Returns true if any of the plots can be watered
+// Run QueryFarmPlots() first
 func (f *Farm) CanWater() bool {
-	since := time.Since(f.LastWateredAt).Hours()
-	return config.CONFIG.Debug.IgnoreWorkCooldown || since > float64(config.CONFIG.Farm.WaterCooldown)
+
+	for _, plot := range f.Plots {
+		plot.QueryCropInfo()
+
+		if plot.CanWater() {
+			return true
+		}
+	}
+	return false
 }
 
-// Returns the time the user can water their crops as a formatted discord string
+// Returns the time the first plot can be watered as a formatted discord string
 // https://hammertime.cyou/
+// Run QueryFarmPlots() first
 func (f *Farm) CanWaterAt() string {
-	nextTime := f.LastWateredAt.Add(time.Hour * config.CONFIG.Farm.WaterCooldown).Unix()
-	return fmt.Sprintf("<t:%d:R>", nextTime)
+
+	var nextTime time.Time
+	for _, plot := range f.Plots {
+		plot.QueryCropInfo()
+
+		if plot.Perished || plot.HasFullyGrown() {
+			continue
+		}
+
+		if at := plot.CanWaterAt(); nextTime.IsZero() || at.Before(nextTime) {
+			nextTime = at
+		}
+	}
+
+	if nextTime.IsZero() {
+		return "once something is planted"
+	}
+	return fmt.Sprintf("<t:%d:R>", nextTime.Unix())
 }
 
 // Returns true if the user can harvest any of their crops
@@ -314,33 +395,43 @@ func (f *Farm) CanHarvest() bool {
 	return false
 }
 
-// Functions waters every single plot
+// Functions waters every plot that can be watered
 // Meaning it will update the plantedAt time
+// Returns the number of plots that were watered
 // Run QueryFarmPlots() before running this function
-func (f *Farm) WaterPlots() {
-
-	// Update last watered at
-	f.LastWateredAt = time.Now()
-	// A change was made so it needs to be saved when farm Save function is called
-	f.PlotsChanged = true
+func (f *Farm) WaterPlots() int {
 
+	watered := 0
 	for _, plot := range f.Plots {
-		plot.Water()
+		plot.QueryCropInfo()
+
+		if plot.Water() {
+			watered++
+		}
 	}
+
+	// A change was made so it needs to be saved when farm Save function is called
+	if watered > 0 {
+		f.PlotsChanged = true
+	}
+	return watered
 }
 
-type harvestResult struct {
-	Name    string
-	Emoji   string
-	Earning int
+type HarvestResult struct {
+	Name         string
+	Emoji        string
+	Earning      int
+	EatenByCrows bool // The crop was lost to a random event
+	Fertilized   bool // The earning was boosted by fertilizer
+	InSeason     bool // The earning was boosted by the season
 }
 
 // Returns an array containing the crop object that was harvested
 // Money earned is saved in f.HarvestEarnings. Remember to add it to the user's balance
 // Run QueryFarmPlots() before running this function
-func (f *Farm) HarvestPlots() []harvestResult {
+func (f *Farm) HarvestPlots() []HarvestResult {
 
-	var result []harvestResult
+	var result []HarvestResult
 
 	for _, plot := range f.Plots {
 
@@ -351,67 +442,119 @@ func (f *Farm) HarvestPlots() []harvestResult {
 		}
 
 		if !plot.HasPerished() {
-			result = append(result,
-				harvestResult{
-					Name:    plot.Crop.Name,
-					Emoji:   plot.Crop.Emoji,
-					Earning: plot.Crop.HarvestReward,
-				})
-
-			f.HarvestEarnings += plot.Crop.HarvestReward
+			result = append(result, f.harvestCrop(plot))
 		}
 
 		// Delete from the database
 		defer f.DeletePlot(plot) // We cannot delete it at once, because we are iterating over it
 	}
 
+	if len(result) > 0 {
+		f.useFertilizer()
+	}
+
 	return result
 }
 
-func (f *Farm) SuccessfulHarvest() bool {
-	return f.HarvestEarnings > 0
-}
+// HarvestPlot harvests a single plot. Perished crops are removed without any earnings
+// Money earned is saved in f.HarvestEarnings. Remember to add it to the user's balance
+// Returns false if the plot does not exist or is not ready to be harvested
+func (f *Farm) HarvestPlot(plotID uint) (HarvestResult, bool) {
+
+	plot := f.GetPlotByID(plotID)
+	if plot == nil {
+		return HarvestResult{}, false
+	}
+
+	plot.QueryCropInfo()
+	plot.CheckPerished(f.SprinklerUntil)
+
+	if !plot.HasFullyGrown() && !plot.HasPerished() {
+		return HarvestResult{}, false
+	}
+
+	result := HarvestResult{
+		Name:  plot.Crop.Name,
+		Emoji: plot.Crop.Emoji,
+	}
+
+	if !plot.HasPerished() {
+		result = f.harvestCrop(plot)
+		f.useFertilizer()
+	}
 
-func (f *Farm) MissedWaterDeadline() bool {
-	return time.Since(f.LastWateredAt).Hours() > float64(config.CONFIG.Farm.CropsPreishAfter)
+	f.DeletePlot(plot)
+	return result, true
 }
 
-// Will return the amount of crops that have perished from plots
-// Will also remove perished crop plots from the database
-// Remember to have call GetFarmPlots() before calling this function
-func (f *Farm) CropsPerishedCheck() []string {
+// harvestCrop calculates the earning for a fully grown crop, with the upgrades and random events applied
+func (f *Farm) harvestCrop(plot *FarmPlot) HarvestResult {
 
-	// Checks if the user has watered their crops in the last x hours
-	if !f.MissedWaterDeadline() {
-		return []string{}
+	result := HarvestResult{
+		Name:  plot.Crop.Name,
+		Emoji: plot.Crop.Emoji,
 	}
 
-	// User has not watered in the last n hours. Thus missing the set deadline
-	// Crops not fully grown (ready to be harvested) will perish
+	if rand.Float64() < f.CropLossChance() {
+		result.EatenByCrows = true
+		return result
+	}
 
-	var perishedCrops []string
+	now := getFarmEngine().Now()
+	result.Earning = int(float64(plot.Crop.RewardAt(now)) * f.PrestigeMultiplier())
+	result.InSeason = plot.Crop.IsInSeason(SeasonAt(now))
+	f.addHarvest(&plot.Crop)
+	if f.Fertilized {
+		result.Earning = int(float64(result.Earning) * config.CONFIG.Farm.FertilizerYieldMultiplier)
+		result.Fertilized = true
+	}
 
+	f.HarvestEarnings += result.Earning
+	return result
+}
+
+// UprootPlot removes the crop from the plot without any earnings
+// Returns the crop that was removed and false if the plot does not exist
+func (f *Farm) UprootPlot(plotID uint) (FarmCrop, bool) {
+
+	plot := f.GetPlotByID(plotID)
+	if plot == nil {
+		return FarmCrop{}, false
+	}
+
+	plot.QueryCropInfo()
+	f.DeletePlot(plot)
+	return plot.Crop, true
+}
+
+// Returns the plot with the given ID or nil if the farm does not have it
+// Run QueryFarmPlots() first
+func (f *Farm) GetPlotByID(plotID uint) *FarmPlot {
 	for _, plot := range f.Plots {
+		if plot.ID == plotID {
+			return plot
+		}
+	}
+	return nil
+}
 
-		plot.QueryCropInfo()
+func (f *Farm) SuccessfulHarvest() bool {
+	return f.HarvestEarnings > 0
+}
 
-		if plot.HasFullyGrown() {
-			// Crop has fully grown so we need to check if the user didn't
-			// just wait the whole growth duration without watering it
+// Will return the names of the crops that have perished
+// Will also remove perished crop plots from the database
+// Remember to have call GetFarmPlots() before calling this function
+func (f *Farm) CropsPerishedCheck() []string {
 
-			crop := &plot.Crop
-			// Calc the time when the crop will be fully grown
-			fullyGrownAt := plot.PlantedAt.Add(crop.DurationToGrow)
+	var perishedCrops []string
 
-			// Calc the time when the crop would have had to be watered
-			waterDeadline := fullyGrownAt.Add(time.Hour * config.CONFIG.Farm.CropsPreishAfter * -1)
+	f.updatePlots()
+
+	for _, plot := range f.Plots {
 
-			// If the last time the plant was watered is after the deadline
-			// Then the crop is ok, lse it will perish
-			if f.LastWateredAt.After(waterDeadline) {
-				// The crop is ok
-				continue
-			}
+		if !plot.HasPerished() {
+			continue
 		}
 
 		perishedCrops = append(perishedCrops, plot.Crop.Name)
@@ -446,14 +589,37 @@ func (f *Farm) CalcFarmPlotPrice() int {
 			float64(f.OwnedPlots-1))))
 }
 
+// PlotsValue returns what the user has paid for the plots they have bought. The default plots are free
+func (f *Farm) PlotsValue() int {
+
+	value := 0
+	bought := Farm{OwnedPlots: config.CONFIG.Farm.DefaultOwnedFarmPlots}
+	for ; bought.OwnedPlots < f.OwnedPlots; bought.OwnedPlots++ {
+		value += bought.CalcFarmPlotPrice()
+	}
+	return value
+}
+
 func (f *Farm) CreateEmbedDescription() string {
 
-	description := fmt.Sprintf("You currently own %d plot", f.OwnedPlots)
+	owner := "You currently own"
+	if f.IsGuildFarm {
+		owner = "The guild owns"
+	}
+
+	description := fmt.Sprintf("%s %d plot", owner, f.OwnedPlots)
 
 	if f.OwnedPlots > 1 {
 		description += "s"
 	}
 
+	if f.PrestigeLevel > 0 {
+		description += fmt.Sprintf("\n⭐ Prestige level %d. Your harvests yield %.2fx", f.PrestigeLevel, f.PrestigeMultiplier())
+	}
+
+	description += f.UpgradesDescription()
+	description += "\n\n" + SeasonAndWeatherDescription(getFarmEngine().Now())
+
 	return description
 }
 
@@ -466,9 +632,14 @@ func (f *Farm) CreateEmbedFields() []*discordgo.MessageEmbedField {
 
 		p.QueryCropInfo()
 
+		value := p.HarvestableAt()
+		if status := p.WaterStatus(f.SprinklerUntil); len(status) > 0 {
+			value += "\n" + status
+		}
+
 		fields = append(fields, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("%d) %s %s", i+1, p.Crop.Emoji, p.Crop.Name),
-			Value:  p.HarvestableAt(),
+			Name:   fmt.Sprintf("%d) %s %s", i+1, p.StageEmoji(), p.Crop.Name),
+			Value:  value,
 			Inline: true,
 		})
 	}
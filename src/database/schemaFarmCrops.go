@@ -6,15 +6,19 @@ import (
 	"strings"
 	"time"
 
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
 	"github.com/CarlFlo/malm"
 )
 
 type FarmCrop struct {
 	Model
-	Name           string
-	Emoji          string
-	DurationToGrow time.Duration
-	HarvestReward  int
+	Name             string
+	Emoji            string
+	DurationToGrow   time.Duration
+	HarvestReward    int
+	SeedPrice        int
+	UnlockHarvests   int    // Harvests of the previous crop needed to unlock this crop. 0 uses the config value
+	PreferredSeasons string // Comma separated. e.g. "Spring,Summer"
 }
 
 func (FarmCrop) TableName() string {
@@ -32,6 +36,67 @@ func (fc *FarmCrop) GetCropByName(name string) bool {
 	return ok.RowsAffected > 0
 }
 
+// RequiredHarvests returns how many times the previous crop must be harvested to unlock this crop
+func (fc *FarmCrop) RequiredHarvests() int {
+	if fc.UnlockHarvests > 0 {
+		return fc.UnlockHarvests
+	}
+	return config.CONFIG.Farm.HarvestsToUnlockNextCrop
+}
+
+// GetPreferredSeasons returns the seasons the crop gives a bonus in
+func (fc *FarmCrop) GetPreferredSeasons() []Season {
+
+	var preferred []Season
+	for _, name := range strings.Split(fc.PreferredSeasons, ",") {
+		if season, ok := ParseSeason(name); ok {
+			preferred = append(preferred, season)
+		}
+	}
+	return preferred
+}
+
+// IsInSeason returns true if the season is one of the crop's preferred seasons
+func (fc *FarmCrop) IsInSeason(season Season) bool {
+	for _, s := range fc.GetPreferredSeasons() {
+		if s == season {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the emojis of the preferred seasons. e.g. "🌸 🌞"
+func (fc *FarmCrop) PreferredSeasonsEmojis() string {
+	var emojis []string
+	for _, s := range fc.GetPreferredSeasons() {
+		emojis = append(emojis, s.Emoji())
+	}
+	return strings.Join(emojis, " ")
+}
+
+// RewardAt returns the reward for harvesting the crop at the given time.
+// Crops in season and good weather earn more
+func (fc *FarmCrop) RewardAt(t time.Time) int {
+
+	multiplier := WeatherAt(t).RewardMultiplier
+	if fc.IsInSeason(SeasonAt(t)) {
+		multiplier *= config.CONFIG.Farm.InSeasonRewardMultiplier
+	}
+
+	return int(float64(fc.HarvestReward) * multiplier)
+}
+
+// GrowthMultiplierAt returns how much faster the crop grows when planted at the given time
+func (fc *FarmCrop) GrowthMultiplierAt(t time.Time) float64 {
+
+	multiplier := WeatherAt(t).GrowthMultiplier
+	if fc.IsInSeason(SeasonAt(t)) {
+		multiplier *= config.CONFIG.Farm.InSeasonGrowthMultiplier
+	}
+	return multiplier
+}
+
 // Outputs the duration in a pretty format
 // Example: 10 days; 1 day; 16 hours; 1 hour; 20 mins
 // Does not handle days with hours, or hours with minutes
@@ -6,16 +6,47 @@ import (
 
 	"github.com/CarlFlo/DiscordMoneyBot/src/config"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type FarmPlot struct {
 	Model
-	FarmID    uint `gorm:"index"`
-	Farm      Farm `gorm:"references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"` // The farm this plot belongs to
-	CropID    int
-	Crop      FarmCrop  `gorm:"references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"` // The planted crop
-	PlantedAt time.Time // When the user planted the crop
-	Perished  bool      // Perished crops wont yeild any money
+	FarmID        uint `gorm:"index"`
+	Farm          Farm `gorm:"references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"` // The farm this plot belongs to
+	CropID        int
+	Crop          FarmCrop      `gorm:"references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"` // The planted crop
+	PlantedAt     time.Time     // When the user planted the crop
+	LastWateredAt time.Time     // Each plot is watered on its own
+	GrowthBoost   time.Duration // Growth time removed by watering
+	// Decided by the season and weather when the crop was planted. Above 1 grows faster
+	GrowthMultiplier float64
+	Perished         bool // Perished crops wont yeild any money
+}
+
+// The stages a crop goes through while growing
+type GrowthStage uint8
+
+const (
+	StageSeed GrowthStage = iota
+	StageSprout
+	StageGrowing
+	StageReady
+	StagePerished
+)
+
+func (gs GrowthStage) String() string {
+	switch gs {
+	case StageSeed:
+		return "Seed"
+	case StageSprout:
+		return "Sprout"
+	case StageGrowing:
+		return "Growing"
+	case StageReady:
+		return "Ready"
+	default:
+		return "Perished"
+	}
 }
 
 func (FarmPlot) TableName() string {
@@ -24,7 +55,9 @@ func (FarmPlot) TableName() string {
 
 func (fp *FarmPlot) BeforeCreate(tx *gorm.DB) error {
 
-	fp.PlantedAt = time.Now()
+	engine := getFarmEngine()
+	engine.Plant(fp)
+	fp.GrowthMultiplier = fp.Crop.GrowthMultiplierAt(fp.PlantedAt)
 	return nil
 }
 
@@ -34,8 +67,9 @@ func (fp *FarmPlot) AfterCreate(tx *gorm.DB) error {
 }
 
 // Saves the data to the database
+// The farm and crop are not saved with it. The copies on the plot could overwrite newer data
 func (fp *FarmPlot) Save() {
-	DB.Save(&fp)
+	DB.Omit(clause.Associations).Save(fp)
 }
 
 // Removes the entry from the database
@@ -54,22 +88,84 @@ func (fp *FarmPlot) QueryCropInfo() {
 	DB.Raw("SELECT * FROM farmCrops WHERE farmCrops.ID = ?", fp.CropID).First(&fp.Crop)
 }
 
-// Check if the crop has fully grown by comparing the duration to grow
-// with the planted at time and the current time
+// GrowDuration returns how long the crop takes to grow with the season and weather applied
+// Call QueryCropInfo() first
+func (fp *FarmPlot) GrowDuration() time.Duration {
+	return getFarmEngine().GrowDuration(fp)
+}
+
+// Check if the crop has fully grown
 // Call QueryCropInfo() first
 func (fp *FarmPlot) HasFullyGrown() bool {
-	return time.Now().After(fp.PlantedAt.Add(fp.Crop.DurationToGrow))
+	return getFarmEngine().HasFullyGrown(fp)
 }
 
 func (fp *FarmPlot) HasPerished() bool {
 	return fp.Perished
 }
 
-// Wateres the plot by updating the PlantedAt time
-func (fp *FarmPlot) Water() {
+// Wateres the plot, reducing the time left until the crop is fully grown
+// Call QueryCropInfo() first
+func (fp *FarmPlot) Water() bool {
+	return getFarmEngine().Water(fp)
+}
+
+// Returns true if the plot can be watered. Fully grown and perished crops do not need water
+// Call QueryCropInfo() first
+func (fp *FarmPlot) CanWater() bool {
+	return getFarmEngine().CanWater(fp)
+}
+
+// Returns the time when the plot can be watered again
+func (fp *FarmPlot) CanWaterAt() time.Time {
+	return getFarmEngine().CanWaterAt(fp)
+}
 
-	// The plantedAt time is moved back - Not moving back the time the set amount for some reason
-	fp.PlantedAt = fp.PlantedAt.Add(time.Hour * config.CONFIG.Farm.WaterCropTimeReductionHours * -1)
+// CheckPerished marks the crop as perished if it was not watered in time.
+// Returns true if the crop perished now. Call QueryCropInfo() first
+func (fp *FarmPlot) CheckPerished(sprinklerUntil time.Time) bool {
+	return getFarmEngine().CheckPerished(fp, sprinklerUntil)
+}
+
+// Returns the current growth stage of the crop
+// Call QueryCropInfo() first
+func (fp *FarmPlot) GrowthStage() GrowthStage {
+	return getFarmEngine().GrowthStage(fp)
+}
+
+// Returns the emoji that represents the growth stage of the crop
+func (fp *FarmPlot) StageEmoji() string {
+
+	switch fp.GrowthStage() {
+	case StageSeed:
+		return config.CONFIG.Emojis.SeedStage
+	case StageSprout:
+		return config.CONFIG.Emojis.SproutStage
+	case StageGrowing:
+		return config.CONFIG.Emojis.GrowingStage
+	case StageReady:
+		return fp.Crop.Emoji
+	default:
+		return config.CONFIG.Emojis.PerishedCrop
+	}
+}
+
+// Returns a discord formatted string showing when the plot can be watered again
+func (fp *FarmPlot) WaterStatus(sprinklerUntil time.Time) string {
+
+	engine := getFarmEngine()
+
+	if fp.Perished || engine.HasFullyGrown(fp) {
+		return ""
+	}
+
+	if engine.CanWater(fp) && engine.Now().Before(sprinklerUntil) {
+		return "Kept wet by the sprinkler"
+	} else if engine.CanWater(fp) {
+		return "Needs water!"
+	}
+
+	return fmt.Sprintf("Water again <t:%d:R>", engine.CanWaterAt(fp).Unix())
 }
 
 // Returns a discord formatted string showing when the crop will be harvestable
@@ -79,12 +175,10 @@ func (fp *FarmPlot) HarvestableAt() string {
 		return "``Plant has perished``"
 	}
 
-	fullyGrown := fp.PlantedAt.Add(fp.Crop.DurationToGrow)
-
-	// time after
-	if time.Now().After(fullyGrown) {
+	engine := getFarmEngine()
+	if engine.HasFullyGrown(fp) {
 		return "Now!"
 	}
 
-	return fmt.Sprintf("<t:%d:R>", fullyGrown.Unix())
+	return fmt.Sprintf("<t:%d:R>", engine.FullyGrownAt(fp).Unix())
 }
@@ -0,0 +1,146 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+)
+
+/*
+	Crops are unlocked in ID order. Harvesting enough of the newest unlocked crop unlocks the next one.
+	How many harvests are needed is set per crop in the crops file.
+	When every crop is unlocked the farm can prestige. It resets the plots and unlocks,
+	but gives a permanent yield multiplier
+*/
+
+// FarmCropHarvest keeps track of how many times a farm has harvested a crop
+type FarmCropHarvest struct {
+	Model
+	FarmID uint `gorm:"index"`
+	CropID uint
+	Count  int
+}
+
+func (FarmCropHarvest) TableName() string {
+	return "userFarmCropHarvests"
+}
+
+// QueryHarvestCounts returns how many times each crop has been harvested. The crop ID is the key
+func (f *Farm) QueryHarvestCounts() map[uint]int {
+
+	var harvests []FarmCropHarvest
+	DB.Where("farm_id = ?", f.ID).Find(&harvests)
+
+	counts := map[uint]int{}
+	for _, h := range harvests {
+		counts[h.CropID] = h.Count
+	}
+	return counts
+}
+
+// addHarvest counts the harvest of the crop and unlocks the next crop when enough have been harvested
+func (f *Farm) addHarvest(crop *FarmCrop) {
+
+	var harvest FarmCropHarvest
+	DB.Where("farm_id = ? AND crop_id = ?", f.ID, crop.ID).First(&harvest)
+
+	harvest.FarmID = f.ID
+	harvest.CropID = crop.ID
+	harvest.Count++
+	DB.Save(&harvest)
+
+	// Saved right away, so the achievements see it
+	f.TotalHarvests++
+	DB.Model(&Farm{}).Where("id = ?", f.ID).UpdateColumn("total_harvests", f.TotalHarvests)
+
+	if crop.ID != uint(f.HighestPlantedCropIndex) {
+		return
+	}
+
+	// The last crop has nothing more to unlock
+	var next FarmCrop
+	if DB.First(&next, crop.ID+1).RowsAffected == 0 || harvest.Count < next.RequiredHarvests() {
+		return
+	}
+
+	f.HighestPlantedCropIndex++
+	f.UnlockedNewCrop = true
+}
+
+func countCrops() int {
+	var count int64
+	DB.Model(&FarmCrop{}).Count(&count)
+	return int(count)
+}
+
+// HasUnlockedAllCrops returns true if the farm can prestige
+func (f *Farm) HasUnlockedAllCrops() bool {
+	return int(f.HighestPlantedCropIndex) >= countCrops()
+}
+
+// PrestigeMultiplier returns the permanent yield multiplier from prestiging
+func (f *Farm) PrestigeMultiplier() float64 {
+	return 1 + float64(f.PrestigeLevel)*config.CONFIG.Farm.PrestigeYieldBonus
+}
+
+// Prestige resets the plots, the unlocked crops and the harvest counts for a permanent yield multiplier
+// Returns false if the farm has not unlocked every crop
+func (f *Farm) Prestige() bool {
+
+	if !f.HasUnlockedAllCrops() {
+		return false
+	}
+
+	f.QueryFarmPlots()
+	for len(f.Plots) > 0 {
+		f.DeletePlot(f.Plots[0])
+	}
+
+	DB.Where("farm_id = ?", f.ID).Delete(&FarmCropHarvest{})
+
+	f.PrestigeLevel++
+	f.OwnedPlots = config.CONFIG.Farm.DefaultOwnedFarmPlots
+	f.HighestPlantedCropIndex = 1
+	return true
+}
+
+// CropProgress describes how far the farm has come with unlocking a crop
+type CropProgress struct {
+	Crop      FarmCrop
+	Unlocked  bool
+	Harvested int
+	// Text describing what is needed to unlock the crop. Empty for unlocked crops
+	Requirement string
+}
+
+// GetCropProgress returns the unlock progress for every crop, in the order they are unlocked
+func (f *Farm) GetCropProgress() []CropProgress {
+
+	var crops []FarmCrop
+	DB.Order("id asc").Find(&crops)
+
+	counts := f.QueryHarvestCounts()
+
+	var progress []CropProgress
+	for i, crop := range crops {
+
+		p := CropProgress{
+			Crop:      crop,
+			Unlocked:  f.HasUserUnlocked(&crop),
+			Harvested: counts[crop.ID],
+		}
+
+		if !p.Unlocked && i > 0 {
+			previous := crops[i-1]
+			if f.HasUserUnlocked(&previous) {
+				required := crop.RequiredHarvests()
+				p.Requirement = fmt.Sprintf("Harvest %s %s %d more times (%d/%d)", previous.Emoji, previous.Name, required-counts[previous.ID], counts[previous.ID], required)
+			} else {
+				p.Requirement = fmt.Sprintf("Unlock %s %s first", previous.Emoji, previous.Name)
+			}
+		}
+
+		progress = append(progress, p)
+	}
+	return progress
+}
@@ -0,0 +1,168 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/bwmarrin/discordgo"
+)
+
+/*
+	Upgrades the user can buy for their farm
+	Fertilizer: The next harvest yields more. Used up when harvesting
+	Sprinkler: The plots count as watered for a while. Buying it again extends the time
+	Scarecrow: Lowers the chance of crows eating the crops when harvesting. Lasts forever
+*/
+
+type FarmUpgrade struct {
+	Name        string
+	Emoji       string
+	Description string
+	Price       int
+}
+
+// GetFarmUpgrades returns the upgrades that can be bought, with the prices from the config
+func GetFarmUpgrades() []FarmUpgrade {
+	return []FarmUpgrade{
+		{
+			Name:        "fertilizer",
+			Emoji:       "💩",
+			Description: fmt.Sprintf("Your next harvest yields %.1fx the money", config.CONFIG.Farm.FertilizerYieldMultiplier),
+			Price:       config.CONFIG.Farm.FertilizerPrice,
+		},
+		{
+			Name:        "sprinkler",
+			Emoji:       "🚿",
+			Description: fmt.Sprintf("Your plots count as watered for %d hours", config.CONFIG.Farm.SprinklerDurationHours),
+			Price:       config.CONFIG.Farm.SprinklerPrice,
+		},
+		{
+			Name:        "scarecrow",
+			Emoji:       "🎃",
+			Description: fmt.Sprintf("Lowers the chance of crows eating your crops from %.0f%% to %.0f%%", config.CONFIG.Farm.CropLossChance*100, config.CONFIG.Farm.CropLossChance*(1-config.CONFIG.Farm.ScarecrowProtection)*100),
+			Price:       config.CONFIG.Farm.ScarecrowPrice,
+		},
+	}
+}
+
+// GetFarmUpgradeByName returns the upgrade with the name. False if there is no such upgrade
+func GetFarmUpgradeByName(name string) (FarmUpgrade, bool) {
+	for _, upgrade := range GetFarmUpgrades() {
+		if upgrade.Name == name {
+			return upgrade, true
+		}
+	}
+	return FarmUpgrade{}, false
+}
+
+// CanBuyUpgrade returns true if the farm does not already have the upgrade.
+// The sprinkler can always be bought to extend the time it is running
+func (f *Farm) CanBuyUpgrade(upgrade FarmUpgrade) bool {
+	switch upgrade.Name {
+	case "fertilizer":
+		return !f.Fertilized
+	case "scarecrow":
+		return !f.HasScarecrow
+	}
+	return true
+}
+
+// ApplyUpgrade gives the farm the upgrade. The user has to pay for it first
+func (f *Farm) ApplyUpgrade(upgrade FarmUpgrade) {
+
+	switch upgrade.Name {
+	case "fertilizer":
+		f.Fertilized = true
+	case "sprinkler":
+		duration := time.Hour * time.Duration(config.CONFIG.Farm.SprinklerDurationHours)
+		// Extends the time if the sprinkler already is running
+		if f.SprinklerActive() {
+			f.SprinklerUntil = f.SprinklerUntil.Add(duration)
+		} else {
+			f.SprinklerUntil = getFarmEngine().Now().Add(duration)
+		}
+	case "scarecrow":
+		f.HasScarecrow = true
+	}
+}
+
+// Returns true if the sprinkler is watering the plots
+func (f *Farm) SprinklerActive() bool {
+	return getFarmEngine().Now().Before(f.SprinklerUntil)
+}
+
+// useFertilizer is called after a harvest. The fertilizer only lasts for one harvest
+func (f *Farm) useFertilizer() {
+	f.Fertilized = false
+}
+
+// CropLossChance returns the chance that crows eat a crop when it is harvested
+func (f *Farm) CropLossChance() float64 {
+	if f.HasScarecrow {
+		return config.CONFIG.Farm.CropLossChance * (1 - config.CONFIG.Farm.ScarecrowProtection)
+	}
+	return config.CONFIG.Farm.CropLossChance
+}
+
+// Returns a text describing the active upgrades. Empty if there are none
+func (f *Farm) UpgradesDescription() string {
+
+	var description string
+
+	if f.Fertilized {
+		description += fmt.Sprintf("\n💩 Fertilized! Your next harvest yields %.1fx the money", config.CONFIG.Farm.FertilizerYieldMultiplier)
+	}
+	if f.SprinklerActive() {
+		description += fmt.Sprintf("\n🚿 The sprinkler is watering your plots until <t:%d:f>", f.SprinklerUntil.Unix())
+	}
+	if f.HasScarecrow {
+		description += "\n🎃 A scarecrow is keeping the crows away"
+	}
+
+	return description
+}
+
+// overviewCreateUpgradeMenu creates the menu for buying upgrades to the farm
+func (f *Farm) overviewCreateUpgradeMenu(msgCompondents *[]discordgo.MessageComponent, user *User) {
+
+	// Upgrades are only for personal farms
+	if f.IsGuildFarm {
+		return
+	}
+
+	options := []discordgo.SelectMenuOption{}
+
+	for _, upgrade := range GetFarmUpgrades() {
+
+		if !f.CanBuyUpgrade(upgrade) || !user.CanAfford(uint64(upgrade.Price)) {
+			continue
+		}
+
+		options = append(options, discordgo.SelectMenuOption{
+			Label:       fmt.Sprintf("Buy %s (%s %s)", upgrade.Name, utils.HumanReadableNumber(upgrade.Price), config.CONFIG.Economy.Name),
+			Value:       upgrade.Name,
+			Description: upgrade.Description,
+			Emoji: discordgo.ComponentEmoji{
+				Name: upgrade.Emoji,
+			},
+		})
+	}
+
+	// The user can't afford any of the upgrades
+	if len(options) == 0 {
+		return
+	}
+
+	*msgCompondents = append(*msgCompondents, discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			&discordgo.SelectMenu{
+				CustomID:    "FBU", // 'FBU' is code for 'Farm Buy Upgrade'
+				Placeholder: "Select an upgrade to buy for your farm",
+				MaxValues:   1,
+				Options:     options,
+			},
+		},
+	})
+}
@@ -0,0 +1,257 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/bwmarrin/discordgo"
+)
+
+/*
+	A guild farm is a farm shared by the members of a guild.
+	The farm belongs to a guild account (a user without a Discord account) which holds the credits the members contribute.
+	Plots and seeds are paid with the contributed credits, and the earnings from harvests are split
+	between the members proportionally to how much they have contributed
+*/
+
+// The discord ID of the account that owns the guild farm is this prefix followed by the guild ID
+const guildFarmAccountPrefix = "guild:"
+
+type GuildFarm struct {
+	Model
+	GuildID string `gorm:"uniqueIndex"`
+	UserID  uint   // The guild account
+}
+
+func (GuildFarm) TableName() string {
+	return "guildFarms"
+}
+
+// GuildFarmContribution is the total amount a member has contributed to a guild farm
+type GuildFarmContribution struct {
+	Model
+	GuildFarmID uint `gorm:"index"`
+	DiscordID   string
+	Amount      uint64
+}
+
+func (GuildFarmContribution) TableName() string {
+	return "guildFarmContributions"
+}
+
+// GuildFarmLog is an entry in the activity log of a guild farm
+type GuildFarmLog struct {
+	Model
+	GuildFarmID uint `gorm:"index"`
+	DiscordID   string
+	Message     string
+}
+
+func (GuildFarmLog) TableName() string {
+	return "guildFarmLogs"
+}
+
+// GuildFarmPayout is what a member received from a harvest on the guild farm
+type GuildFarmPayout struct {
+	DiscordID string
+	Amount    uint64
+}
+
+// QueryGuildFarm queries the guild farm of the guild. It is created along with the guild account if it does not exist
+func (gf *GuildFarm) QueryGuildFarm(guildID string) {
+
+	DB.Where("guild_id = ?", guildID).First(&gf)
+	if gf.ID != 0 {
+		return
+	}
+
+	// The account starts without any money. Everything it has is contributed by the members
+	account := User{DiscordID: guildFarmAccountPrefix + guildID}
+	DB.Create(&account)
+
+	gf.GuildID = guildID
+	gf.UserID = account.ID
+	DB.Create(&gf)
+}
+
+// QueryAccount updates the user object with the guild account
+func (gf *GuildFarm) QueryAccount(account *User) {
+	DB.First(&account, gf.UserID)
+}
+
+// QueryGuildFarmData queries the farm that belongs to the guild account
+func (f *Farm) QueryGuildFarmData(account *User) {
+	f.QueryUserFarmData(account)
+	f.IsGuildFarm = true
+}
+
+// Contribute moves credits from the member to the guild account
+// Remember to save both the member and the account
+func (gf *GuildFarm) Contribute(member *User, account *User, amount uint64) {
+
+	member.DeductMoney(amount)
+	account.Money += amount // Contributions are not earnings
+
+	var contribution GuildFarmContribution
+	DB.Where("guild_farm_id = ? AND discord_id = ?", gf.ID, member.DiscordID).First(&contribution)
+
+	contribution.GuildFarmID = gf.ID
+	contribution.DiscordID = member.DiscordID
+	contribution.Amount += amount
+	DB.Save(&contribution)
+
+	gf.AddLog(member.DiscordID, fmt.Sprintf("contributed %s %s", utils.HumanReadableNumber(amount), config.CONFIG.Economy.Name))
+}
+
+// QueryContributions returns the contributions to the guild farm, largest first
+func (gf *GuildFarm) QueryContributions() []GuildFarmContribution {
+	var contributions []GuildFarmContribution
+	DB.Where("guild_farm_id = ?", gf.ID).Order("amount desc").Find(&contributions)
+	return contributions
+}
+
+// SplitEarnings moves the earnings from the guild account to the contributors, proportionally to their contributions.
+// What can't be split evenly stays in the account. The share of the member who harvested is added to member,
+// so it is not lost when the caller saves them. Remember to save the account and the member
+func (gf *GuildFarm) SplitEarnings(account *User, member *User, earnings int) []GuildFarmPayout {
+
+	if earnings <= 0 {
+		return nil
+	}
+
+	payouts := splitProportionally(uint64(earnings), gf.QueryContributions())
+
+	for _, payout := range payouts {
+
+		if payout.DiscordID == member.DiscordID {
+			account.DeductMoney(payout.Amount)
+			member.AddMoney(payout.Amount)
+			continue
+		}
+
+		var contributor User
+		contributor.QueryUserByDiscordID(payout.DiscordID)
+		if contributor.ID == 0 {
+			continue
+		}
+
+		account.DeductMoney(payout.Amount)
+		contributor.AddMoney(payout.Amount)
+		contributor.Save()
+	}
+
+	return payouts
+}
+
+// splitProportionally splits the amount between the contributors. The amounts are rounded down
+func splitProportionally(amount uint64, contributions []GuildFarmContribution) []GuildFarmPayout {
+
+	var total uint64
+	for _, c := range contributions {
+		total += c.Amount
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	var payouts []GuildFarmPayout
+	for _, c := range contributions {
+		if share := amount * c.Amount / total; share > 0 {
+			payouts = append(payouts, GuildFarmPayout{DiscordID: c.DiscordID, Amount: share})
+		}
+	}
+	return payouts
+}
+
+// AddLog adds an entry to the activity log of the guild farm
+func (gf *GuildFarm) AddLog(discordID, message string) {
+	DB.Create(&GuildFarmLog{
+		GuildFarmID: gf.ID,
+		DiscordID:   discordID,
+		Message:     message,
+	})
+}
+
+// QueryLogs returns the newest entries in the activity log, newest first
+func (gf *GuildFarm) QueryLogs(limit int) []GuildFarmLog {
+	var logs []GuildFarmLog
+	DB.Where("guild_farm_id = ?", gf.ID).Order("id desc").Limit(limit).Find(&logs)
+	return logs
+}
+
+// Guild farm components are prefixed with 'G' so the interactions are routed to the guild farm
+func (f *Farm) customID(id string) string {
+	if f.IsGuildFarm {
+		return "G" + id
+	}
+	return id
+}
+
+// CreateGuildFarmOverview creates the message for the guild farm
+func (f *Farm) CreateGuildFarmOverview(msg *discordgo.MessageSend, guild *discordgo.Guild, gf *GuildFarm, account *User) {
+
+	f.QueryFarmPlots()
+
+	// Takes a look at the farm, updating it to reflect the current state
+	f.Peek()
+
+	f.overviewCreateGuildEmbed(&msg.Embeds, guild, gf, account)
+	f.overviewCreateComponents(&msg.Components, account)
+}
+
+// UpdateGuildInteractionOverview updates the guild farm message after an interaction
+func (f *Farm) UpdateGuildInteractionOverview(guild *discordgo.Guild, gf *GuildFarm, account *User, me *discordgo.MessageEdit) {
+
+	f.overviewCreateGuildEmbed(&me.Embeds, guild, gf, account)
+	f.overviewCreateComponents(&me.Components, account)
+}
+
+// The thumbnail does not contain a user ID, so every member of the guild can use the buttons
+func (f *Farm) overviewCreateGuildEmbed(embeds *[]*discordgo.MessageEmbed, guild *discordgo.Guild, gf *GuildFarm, account *User) {
+
+	embed := &discordgo.MessageEmbed{
+		Type:        discordgo.EmbedTypeRich,
+		Color:       config.CONFIG.Colors.Neutral,
+		Title:       fmt.Sprintf("%s's Guild Farm", guild.Name),
+		Description: f.createGuildEmbedDescription(gf, account),
+		Fields:      f.CreateEmbedFields(),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Contribute with '%sfarm guild contribute <amount>' to get a share of the harvests!\nCrops will perish if not watered every day!", config.CONFIG.BotPrefix),
+		},
+	}
+
+	// Not every guild has an icon
+	if icon := guild.IconURL(); len(icon) > 0 {
+		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{URL: icon}
+	}
+
+	*embeds = append(*embeds, embed)
+}
+
+func (f *Farm) createGuildEmbedDescription(gf *GuildFarm, account *User) string {
+
+	contributions := gf.QueryContributions()
+
+	description := fmt.Sprintf("The farm has %s %s to spend from %d contributor", account.PrettyPrintMoney(), config.CONFIG.Economy.Name, len(contributions))
+	if len(contributions) != 1 {
+		description += "s"
+	}
+
+	// The top contributors
+	var top []string
+	for i, c := range contributions {
+		if i == 3 {
+			break
+		}
+		top = append(top, fmt.Sprintf("<@%s> %s", c.DiscordID, utils.HumanReadableNumber(c.Amount)))
+	}
+
+	if len(top) > 0 {
+		description += "\nTop contributors: " + strings.Join(top, ", ")
+	}
+
+	return description + "\n\n" + f.CreateEmbedDescription()
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestSplitProportionally(t *testing.T) {
+
+	contributions := []GuildFarmContribution{
+		{DiscordID: "a", Amount: 600},
+		{DiscordID: "b", Amount: 300},
+		{DiscordID: "c", Amount: 100},
+	}
+
+	payouts := splitProportionally(1000, contributions)
+	test.Validate(t, len(payouts), 3, "Every contributor should get a share")
+	test.Validate(t, payouts[0].Amount, uint64(600), "The largest contributor should get 60%")
+	test.Validate(t, payouts[1].Amount, uint64(300), "The second contributor should get 30%")
+	test.Validate(t, payouts[2].Amount, uint64(100), "The smallest contributor should get 10%")
+
+	// 100 * 100 / 1000 = 10, 100 * 300 / 1000 = 30 and 100 * 600 / 1000 = 60
+	payouts = splitProportionally(101, contributions)
+	var total uint64
+	for _, p := range payouts {
+		total += p.Amount
+	}
+	test.Validate(t, total, uint64(100), "The shares should be rounded down")
+
+	payouts = splitProportionally(5, contributions)
+	test.Validate(t, len(payouts), 2, "Contributors with a share of 0 should be left out")
+
+	test.Validate(t, len(splitProportionally(1000, nil)), 0, "Nothing should be split without contributors")
+}
+
+func TestSplitEarnings(t *testing.T) {
+
+	testDB, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := testDB.AutoMigrate(&User{}, &GuildFarm{}, &GuildFarmContribution{}); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := DB
+	DB = testDB
+	defer func() { DB = previous }()
+
+	account := User{DiscordID: "guild", Money: 1000}
+	harvester := User{DiscordID: "a", Money: 50}
+	other := User{DiscordID: "b", Money: 50}
+	for _, u := range []*User{&account, &harvester, &other} {
+		DB.Create(u)
+	}
+
+	gf := GuildFarm{GuildID: "guild", UserID: account.ID}
+	DB.Create(&gf)
+	DB.Create(&GuildFarmContribution{GuildFarmID: gf.ID, DiscordID: "a", Amount: 300})
+	DB.Create(&GuildFarmContribution{GuildFarmID: gf.ID, DiscordID: "b", Amount: 100})
+
+	// The harvester is saved after the split, the same way the guild farm commands do it
+	gf.SplitEarnings(&account, &harvester, 400)
+	harvester.Save()
+	account.Save()
+
+	var got User
+	got.QueryUserByDiscordID("a")
+	test.Validate(t, got.Money, uint64(350), "The harvester should keep their share")
+	got = User{}
+	got.QueryUserByDiscordID("b")
+	test.Validate(t, got.Money, uint64(150), "The other contributor should get their share")
+	got = User{}
+	got.QueryUserByDiscordID("guild")
+	test.Validate(t, got.Money, uint64(600), "The shares should be taken from the guild account")
+}
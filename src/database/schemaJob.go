@@ -0,0 +1,139 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+)
+
+/*
+	The user works a job. Each shift gives experience in the job, and enough experience gives a promotion
+	which raises the pay. Better jobs require the user to have worked a number of shifts first.
+	Switching job resets the experience
+*/
+
+type Job struct {
+	Name           string
+	Emoji          string
+	MinMoney       int
+	MaxMoney       int
+	Cooldown       time.Duration
+	Experience     int // Earned each shift
+	RequiredShifts int // Shifts worked in any job before the job can be taken
+	Ranks          []string
+}
+
+// GetJobs returns the jobs from the config
+func GetJobs() []Job {
+
+	var jobs []Job
+	for _, j := range config.CONFIG.Work.Jobs {
+
+		ranks := j.Ranks
+		if len(ranks) == 0 {
+			ranks = []string{j.Name}
+		}
+
+		jobs = append(jobs, Job{
+			Name:           j.Name,
+			Emoji:          j.Emoji,
+			MinMoney:       j.MinMoney,
+			MaxMoney:       j.MaxMoney,
+			Cooldown:       time.Hour * j.Cooldown,
+			Experience:     j.Experience,
+			RequiredShifts: j.RequiredShifts,
+			Ranks:          ranks,
+		})
+	}
+	return jobs
+}
+
+// GetJobByName returns the job with the name. False if there is no such job
+func GetJobByName(name string) (Job, bool) {
+	for _, job := range GetJobs() {
+		if strings.EqualFold(job.Name, strings.TrimSpace(name)) {
+			return job, true
+		}
+	}
+	return Job{}, false
+}
+
+// GetJob returns the job the user works. Users without a job, or with a job that was removed, get the first job
+func (w *Work) GetJob() Job {
+	if job, ok := GetJobByName(w.Job); ok {
+		return job
+	}
+	return GetJobs()[0]
+}
+
+// Rank returns how many times the user has been promoted in their job
+func (w *Work) Rank() int {
+
+	rank := 0
+	if config.CONFIG.Work.PromotionExperience > 0 {
+		rank = w.JobExperience / config.CONFIG.Work.PromotionExperience
+	}
+
+	if highest := len(w.GetJob().Ranks) - 1; rank > highest {
+		return highest
+	}
+	return rank
+}
+
+// RankTitle returns the title of the user in their job. e.g. 'Head Chef'
+func (w *Work) RankTitle() string {
+	return w.GetJob().Ranks[w.Rank()]
+}
+
+// HasHighestRank returns true if the user can't be promoted any further
+func (w *Work) HasHighestRank() bool {
+	return w.Rank() == len(w.GetJob().Ranks)-1
+}
+
+// ExperienceToPromotion returns how much experience is needed for the next promotion
+func (w *Work) ExperienceToPromotion() int {
+	return (w.Rank()+1)*config.CONFIG.Work.PromotionExperience - w.JobExperience
+}
+
+// PayMultiplier returns how much more the user earns from their promotions
+func (w *Work) PayMultiplier() float64 {
+	return 1 + float64(w.Rank())*config.CONFIG.Work.PromotionPayBonus
+}
+
+// CanTakeJob returns true if the user has worked enough shifts for the job
+func (w *Work) CanTakeJob(job Job) bool {
+	return w.Shifts >= job.RequiredShifts
+}
+
+// SwitchJob changes the job of the user. The experience from the old job is lost
+func (w *Work) SwitchJob(job Job) {
+	w.Job = job.Name
+	w.JobExperience = 0
+}
+
+// addShift gives the user experience for working a shift and sets w.Promoted if it was enough for a promotion
+func (w *Work) addShift() {
+
+	rank := w.Rank()
+
+	w.Job = w.GetJob().Name
+	w.Shifts++
+	w.JobExperience += w.GetJob().Experience
+
+	w.Promoted = w.Rank() > rank
+}
+
+// JobDescription describes the job of the user and the progress towards the next promotion
+func (w *Work) JobDescription() string {
+
+	job := w.GetJob()
+	description := fmt.Sprintf("%s You work as a **%s** (%s)", job.Emoji, w.RankTitle(), job.Name)
+
+	if w.HasHighestRank() {
+		return description + "\nYou have reached the highest rank!"
+	}
+
+	return description + fmt.Sprintf("\n%d experience until your next promotion", w.ExperienceToPromotion())
+}
@@ -0,0 +1,160 @@
+package database
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+)
+
+/*
+	Every activity gives the user XP, and enough XP gives a new level.
+	Each level reached is rewarded once. The highest rewarded level is saved,
+	so changing the XP curve in the config never rewards a level twice
+*/
+
+// Activity is something the user does that gives XP
+type Activity uint8
+
+const (
+	ActivityWork Activity = iota
+	ActivityDaily
+	ActivityHarvest
+	ActivityMusic
+)
+
+// LevelReward is what the user got for reaching a level
+type LevelReward struct {
+	Level         int
+	Money         int
+	StreakFreezes uint8
+}
+
+// ActivityXP returns the XP the activity gives each time it is done
+func ActivityXP(activity Activity) int {
+
+	switch activity {
+	case ActivityWork:
+		return config.CONFIG.Levels.WorkXP
+	case ActivityDaily:
+		return config.CONFIG.Levels.DailyXP
+	case ActivityHarvest:
+		return config.CONFIG.Levels.HarvestXP
+	case ActivityMusic:
+		return config.CONFIG.Levels.MusicXP
+	}
+	return 0
+}
+
+// xpToLevelUp returns the XP needed to go from the level to the next
+func xpToLevelUp(level int, baseXP int, exponent float64) uint64 {
+	return uint64(math.Round(float64(baseXP) * math.Pow(float64(level), exponent)))
+}
+
+// levelForXP returns the level the XP is enough for. Everyone starts at level 1
+func levelForXP(xp uint64, baseXP int, exponent float64) int {
+
+	if baseXP <= 0 {
+		return 1
+	}
+
+	level := 1
+	for needed := xpToLevelUp(level, baseXP, exponent); needed > 0 && xp >= needed; needed = xpToLevelUp(level, baseXP, exponent) {
+		xp -= needed
+		level++
+	}
+	return level
+}
+
+// GetLevel returns the level of the user
+func (u *User) GetLevel() int {
+	return levelForXP(u.XP, config.CONFIG.Levels.BaseXP, config.CONFIG.Levels.Exponent)
+}
+
+// levelProgress returns how much XP the user has in their level, and how much the level needs
+func (u *User) levelProgress() (uint64, uint64) {
+
+	xp := u.XP
+	for level := 1; level < u.GetLevel(); level++ {
+		xp -= xpToLevelUp(level, config.CONFIG.Levels.BaseXP, config.CONFIG.Levels.Exponent)
+	}
+	return xp, xpToLevelUp(u.GetLevel(), config.CONFIG.Levels.BaseXP, config.CONFIG.Levels.Exponent)
+}
+
+// AddActivityXP gives the user XP for doing the activity a number of times and rewards the new levels
+// Returns the rewards for the levels reached. Remember to save the user
+func (u *User) AddActivityXP(activity Activity, times int) []LevelReward {
+
+	if xp := ActivityXP(activity) * times; xp > 0 {
+		u.XP += uint64(xp)
+	}
+
+	var rewards []LevelReward
+	for level := int(u.RewardedLevel) + 1; level <= u.GetLevel(); level++ {
+
+		// Level 1 is where everyone starts
+		if level == 1 {
+			continue
+		}
+
+		reward := levelRewardFor(level)
+		u.AddMoney(uint64(reward.Money))
+
+		// Never more than the user can own
+		given := uint8(0)
+		for ; given < reward.StreakFreezes && !u.HasMaxStreakFreezes(); given++ {
+			u.StreakFreezes++
+		}
+		reward.StreakFreezes = given
+
+		rewards = append(rewards, reward)
+	}
+
+	if level := u.GetLevel(); level > int(u.RewardedLevel) {
+		u.RewardedLevel = uint16(level)
+	}
+
+	return rewards
+}
+
+// levelRewardFor returns the reward for reaching the level
+func levelRewardFor(level int) LevelReward {
+
+	reward := LevelReward{Level: level, Money: config.CONFIG.Levels.RewardPerLevel * level}
+	for _, r := range config.CONFIG.Levels.Rewards {
+		if r.Level == level {
+			reward.Money += r.Money
+			reward.StreakFreezes += r.StreakFreezes
+		}
+	}
+	return reward
+}
+
+// LevelDescription describes the level of the user and the progress towards the next
+func (u *User) LevelDescription() string {
+
+	xp, needed := u.levelProgress()
+
+	// A bar of 10 blocks
+	filled := 0
+	if needed > 0 {
+		filled = int(xp * 10 / needed)
+	}
+	bar := strings.Repeat("▰", filled) + strings.Repeat("▱", 10-filled)
+
+	return fmt.Sprintf("**Level %d** %s %s/%s XP", u.GetLevel(), bar, utils.HumanReadableNumber(xp), utils.HumanReadableNumber(needed))
+}
+
+// String describes the reward. e.g. '1,000 credits and 1 streak freeze'
+func (lr LevelReward) String() string {
+
+	text := fmt.Sprintf("%s %s", utils.HumanReadableNumber(lr.Money), config.CONFIG.Economy.Name)
+	if lr.StreakFreezes == 1 {
+		text += " and 1 streak freeze"
+	} else if lr.StreakFreezes > 1 {
+		text += fmt.Sprintf(" and %d streak freezes", lr.StreakFreezes)
+	}
+	return text
+}
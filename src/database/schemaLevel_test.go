@@ -0,0 +1,25 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+func TestXPToLevelUp(t *testing.T) {
+
+	test.Validate(t, xpToLevelUp(1, 100, 1.5), uint64(100), "Level 1 should need the base XP")
+	test.Validate(t, xpToLevelUp(4, 100, 1.5), uint64(800), "Level 4 should need 100 * 4^1.5 XP")
+	test.Validate(t, xpToLevelUp(4, 100, 1), uint64(400), "An exponent of 1 should make the curve linear")
+}
+
+func TestLevelForXP(t *testing.T) {
+
+	test.Validate(t, levelForXP(0, 100, 1), 1, "Everyone should start at level 1")
+	test.Validate(t, levelForXP(99, 100, 1), 1, "Level 2 should not be reached before the base XP")
+	test.Validate(t, levelForXP(100, 100, 1), 2, "Level 2 should be reached with the base XP")
+	test.Validate(t, levelForXP(299, 100, 1), 2, "Level 3 should need 100 + 200 XP")
+	test.Validate(t, levelForXP(300, 100, 1), 3, "Level 3 should be reached with 100 + 200 XP")
+	test.Validate(t, levelForXP(1_000_000, 0, 1), 1, "A base XP of 0 should never level up")
+	test.Validate(t, levelForXP(1_000_000, 100, -10), 2, "XP curves that round down to 0 should not level up forever")
+}
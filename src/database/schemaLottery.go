@@ -0,0 +1,255 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"gorm.io/gorm"
+)
+
+/*
+	There is one lottery, which is drawn at an interval. Users buy tickets, and most of the ticket sales go to the pot.
+	The tickets are saved by the discord ID, and the lottery is kept when the database is reset,
+	so a draw is not lost when the bot is restarted. A draw that was missed while the bot was off is drawn when it starts
+*/
+
+type Lottery struct {
+	Model
+	Pot          uint64
+	DrawAt       time.Time
+	LastWinner   string // The discord ID of the previous winner. Empty if no one has won yet
+	LastPrize    uint64
+	LastTickets  int // The number of tickets in the previous draw
+	TimesDrawn   int
+	TicketsTotal int `gorm:"-"`
+}
+
+func (Lottery) TableName() string {
+	return "lottery"
+}
+
+type LotteryTicket struct {
+	Model
+	DiscordID string `gorm:"uniqueIndex"`
+	Tickets   int
+}
+
+func (LotteryTicket) TableName() string {
+	return "lotteryTickets"
+}
+
+var errLotteryPurchase = errors.New("the tickets could not be bought")
+
+// LotteryDraw is the result of a draw
+type LotteryDraw struct {
+	Winner  string // Empty if no tickets were bought
+	Prize   uint64
+	Tickets int // The tickets of the winner
+	Total   int // All tickets in the draw
+}
+
+// QueryLottery queries the lottery. It is created if it does not exist
+func (l *Lottery) QueryLottery() {
+
+	DB.First(&l)
+	if l.ID == 0 {
+		l.Pot = uint64(config.CONFIG.Lottery.StartingPot)
+		l.DrawAt = time.Now().Add(lotteryInterval())
+		DB.Create(&l)
+	}
+
+	var total int64
+	DB.Model(&LotteryTicket{}).Select("COALESCE(SUM(tickets), 0)").Scan(&total)
+	l.TicketsTotal = int(total)
+}
+
+// TicketsOf returns how many tickets the user has in the current draw
+func (l *Lottery) TicketsOf(discordID string) int {
+
+	var ticket LotteryTicket
+	DB.Where("discord_id = ?", discordID).First(&ticket)
+	return ticket.Tickets
+}
+
+// BuyTickets buys tickets for the user. Most of the price is added to the pot
+// Returns false if the user can't buy them, with the reason in the response
+func (l *Lottery) BuyTickets(user *User, count int, response *string) bool {
+
+	var price uint64
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+
+		var ticket LotteryTicket
+		tx.Where("discord_id = ?", user.DiscordID).First(&ticket)
+
+		// Checked before the price is calculated, so a huge count can't overflow it
+		if count < 1 || count > config.CONFIG.Lottery.MaxTicketsPerUser-ticket.Tickets {
+			*response = fmt.Sprintf("You can have at most %d tickets in each draw! You have %d", config.CONFIG.Lottery.MaxTicketsPerUser, ticket.Tickets)
+			return errLotteryPurchase
+		}
+
+		var ok bool
+		if price, ok = lotteryTicketsPrice(count, config.CONFIG.Lottery.TicketPrice); !ok {
+			*response = "That is too many tickets!"
+			return errLotteryPurchase
+		}
+
+		// The user is queried again, so the money is not spent twice
+		if err := tx.First(user, user.ID).Error; err != nil {
+			return err
+		}
+
+		if !user.CanAfford(price) {
+			*response = fmt.Sprintf("You are lacking ``%s`` %s for this transaction.\nYour balance: ``%s`` %s", utils.HumanReadableNumber(price-user.Money), config.CONFIG.Economy.Name, user.PrettyPrintMoney(), config.CONFIG.Economy.Name)
+			return errLotteryPurchase
+		}
+
+		user.DeductMoney(price)
+		if err := tx.Save(user).Error; err != nil {
+			return err
+		}
+
+		ticket.DiscordID = user.DiscordID
+		ticket.Tickets += count
+		if err := tx.Save(&ticket).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&Lottery{}).Where("id = ?", l.ID).Update("pot", gorm.Expr("pot + ?", lotteryPotShare(price))).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&MoneyHistory{UserID: user.ID, Amount: -int64(price), Reason: "lottery tickets"}).Error
+	})
+
+	if err != nil {
+		if err != errLotteryPurchase {
+			*response = "Could not buy the tickets!"
+		}
+		return false
+	}
+
+	l.QueryLottery()
+	*response = fmt.Sprintf("You bought %d tickets for ``%s`` %s. You have %d tickets in the draw", count, utils.HumanReadableNumber(price), config.CONFIG.Economy.Name, l.TicketsOf(user.DiscordID))
+	return true
+}
+
+// IsDue returns true if it is time to draw the lottery
+func (l *Lottery) IsDue() bool {
+	return !time.Now().Before(l.DrawAt)
+}
+
+// Draw draws a winner, pays them the pot and starts the next draw. The pot is kept for the next draw if no tickets were bought
+func (l *Lottery) Draw() (LotteryDraw, error) {
+
+	var draw LotteryDraw
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+
+		if err := tx.First(l, l.ID).Error; err != nil {
+			return err
+		}
+
+		if time.Now().Before(l.DrawAt) {
+			return errors.New("the lottery is not due yet")
+		}
+
+		var tickets []LotteryTicket
+		tx.Order("id").Find(&tickets)
+
+		for _, t := range tickets {
+			draw.Total += t.Tickets
+		}
+
+		l.DrawAt = nextDrawTime(l.DrawAt, time.Now(), lotteryInterval())
+		l.TimesDrawn++
+
+		if draw.Total == 0 {
+			return tx.Save(l).Error
+		}
+
+		draw.Winner, draw.Tickets = pickLotteryWinner(tickets, rand.Intn(draw.Total))
+		draw.Prize = l.Pot
+
+		var winner User
+		tx.Where("discord_id = ?", draw.Winner).First(&winner)
+		if winner.ID == 0 {
+			// The users are removed when the database is reset, but the tickets are not
+			winner = User{DiscordID: draw.Winner, Money: config.CONFIG.Economy.StartingMoney}
+		}
+
+		winner.AddWinnings(draw.Prize)
+		if err := tx.Save(&winner).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&MoneyHistory{UserID: winner.ID, Amount: int64(draw.Prize), Reason: "lottery"}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("1 = 1").Delete(&LotteryTicket{}).Error; err != nil {
+			return err
+		}
+
+		l.Pot = uint64(config.CONFIG.Lottery.StartingPot)
+		l.LastWinner = draw.Winner
+		l.LastPrize = draw.Prize
+		l.LastTickets = draw.Total
+		return tx.Save(l).Error
+	})
+
+	l.TicketsTotal = 0
+	return draw, err
+}
+
+// pickLotteryWinner returns the owner of the ticket at the position, and how many tickets they have
+// The position is 0 to the number of tickets minus one
+func pickLotteryWinner(tickets []LotteryTicket, position int) (string, int) {
+
+	for _, t := range tickets {
+		if position < t.Tickets {
+			return t.DiscordID, t.Tickets
+		}
+		position -= t.Tickets
+	}
+	return "", 0
+}
+
+// nextDrawTime returns the first draw after now. Draws missed while the bot was off are skipped, so the schedule is kept
+func nextDrawTime(drawAt time.Time, now time.Time, interval time.Duration) time.Time {
+
+	next := drawAt.Add(interval)
+	if next.After(now) {
+		return next
+	}
+
+	missed := now.Sub(next)/interval + 1
+	return next.Add(missed * interval)
+}
+
+// lotteryPotShare returns how much of the ticket sales goes to the pot
+func lotteryPotShare(price uint64) uint64 {
+	return uint64(float64(price) * (1 - config.CONFIG.Lottery.HouseCut))
+}
+
+func lotteryInterval() time.Duration {
+	return time.Hour * time.Duration(config.CONFIG.Lottery.DrawIntervalHours)
+}
+
+// lotteryTicketsPrice returns what the tickets cost. Returns false if the price overflows
+func lotteryTicketsPrice(count int, ticketPrice int) (uint64, bool) {
+
+	if count < 0 || ticketPrice < 0 {
+		return 0, false
+	}
+
+	price := uint64(count) * uint64(ticketPrice)
+	if count != 0 && price/uint64(count) != uint64(ticketPrice) {
+		return 0, false
+	}
+	return price, true
+}
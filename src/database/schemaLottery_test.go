@@ -0,0 +1,56 @@
+package database
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+func TestPickLotteryWinner(t *testing.T) {
+
+	tickets := []LotteryTicket{
+		{DiscordID: "a", Tickets: 2},
+		{DiscordID: "b", Tickets: 5},
+		{DiscordID: "c", Tickets: 1},
+	}
+
+	expected := []string{"a", "a", "b", "b", "b", "b", "b", "c"}
+	for position, winner := range expected {
+		got, _ := pickLotteryWinner(tickets, position)
+		test.Validate(t, got, winner, "The wrong ticket won")
+	}
+
+	_, count := pickLotteryWinner(tickets, 3)
+	test.Validate(t, count, 5, "The tickets of the winner should be returned")
+}
+
+func TestNextDrawTime(t *testing.T) {
+
+	drawAt := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	interval := time.Hour * 24
+
+	next := nextDrawTime(drawAt, drawAt.Add(time.Minute), interval)
+	test.Validate(t, next, drawAt.Add(interval), "The next draw should be one interval later")
+
+	// The bot was off for three and a half days
+	next = nextDrawTime(drawAt, drawAt.Add(time.Hour*84), interval)
+	test.Validate(t, next, drawAt.Add(interval*4), "The missed draws should be skipped")
+
+	next = nextDrawTime(drawAt, drawAt.Add(interval), interval)
+	test.Validate(t, next, drawAt.Add(interval*2), "The next draw should be after now")
+}
+
+func TestLotteryTicketsPrice(t *testing.T) {
+
+	price, ok := lotteryTicketsPrice(3, 100)
+	test.Validate(t, ok, true, "A normal price should not overflow")
+	test.Validate(t, price, uint64(300), "The price should be the count times the ticket price")
+
+	_, ok = lotteryTicketsPrice(math.MaxInt, math.MaxInt)
+	test.Validate(t, ok, false, "A price that overflows should be refused")
+
+	_, ok = lotteryTicketsPrice(-1, 100)
+	test.Validate(t, ok, false, "A negative count should be refused")
+}
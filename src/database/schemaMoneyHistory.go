@@ -0,0 +1,69 @@
+package database
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+/*
+	The money history records what the user won and lost, so they can see where their money went
+*/
+
+type MoneyHistory struct {
+	Model
+	UserID uint  `gorm:"index"`
+	Amount int64 // Negative if the user lost money
+	Reason string
+}
+
+func (MoneyHistory) TableName() string {
+	return "userMoneyHistory"
+}
+
+// RecordMoney adds an entry to the money history of the user
+func (u *User) RecordMoney(amount int64, reason string) {
+	DB.Create(&MoneyHistory{
+		UserID: u.ID,
+		Amount: amount,
+		Reason: reason,
+	})
+}
+
+// QueryMoneyHistory returns the newest entries in the money history, newest first
+func (u *User) QueryMoneyHistory(limit int) []MoneyHistory {
+	var history []MoneyHistory
+	DB.Where("user_id = ?", u.ID).Order("id desc").Limit(limit).Find(&history)
+	return history
+}
+
+// SettleBet gives or takes the difference between the payout and the bet, and records it in the same transaction
+// held is the part of the bet that was already taken from the user, which is given back first
+// Winnings are not counted as earnings. Returns false if the user can't afford the loss
+func (u *User) SettleBet(held uint64, difference int64, reason string) bool {
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+
+		// The user is queried again, so changes made since the bet was placed are kept
+		if err := tx.First(u, u.ID).Error; err != nil {
+			return err
+		}
+
+		u.RefundMoney(held)
+		if difference > 0 {
+			u.AddWinnings(uint64(difference))
+		} else if !u.CanAfford(uint64(-difference)) {
+			return errors.New("the user can't afford the bet")
+		} else {
+			u.DeductMoney(uint64(-difference))
+		}
+
+		if err := tx.Save(u).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&MoneyHistory{UserID: u.ID, Amount: difference, Reason: reason}).Error
+	})
+
+	return err == nil
+}
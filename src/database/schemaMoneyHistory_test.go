@@ -0,0 +1,47 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestSettleBet(t *testing.T) {
+
+	testDB, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := testDB.AutoMigrate(&User{}, &MoneyHistory{}); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := DB
+	DB = testDB
+	defer func() { DB = previous }()
+
+	user := User{DiscordID: "a", Money: 100}
+	DB.Create(&user)
+
+	// Another game changes the money after the bet was placed
+	stale := user
+	DB.Model(&User{}).Where("id = ?", user.ID).Update("money", 500)
+
+	test.Validate(t, stale.SettleBet(0, 50, "coinflip"), true, "A won bet should be settled")
+	test.Validate(t, stale.Money, uint64(550), "The winnings should be added to the current money")
+	test.Validate(t, stale.LifetimeEarnings, uint64(0), "Winnings should not count as earnings")
+
+	test.Validate(t, stale.SettleBet(0, -600, "slots"), false, "A bet the user can't afford should not be settled")
+	test.Validate(t, stale.SettleBet(600, -600, "blackjack"), true, "A lost bet that was already taken should be settled")
+
+	var got User
+	got.QueryUserByDiscordID("a")
+	test.Validate(t, got.Money, uint64(550), "The money should only change by the settled bets")
+	test.Validate(t, len(got.QueryMoneyHistory(10)), 2, "Only the settled bets should be in the money history")
+}
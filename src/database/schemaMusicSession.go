@@ -0,0 +1,83 @@
+package database
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MusicSession is the saved state of a guild's music player.
+// Used to resume the music after the bot has been restarted
+type MusicSession struct {
+	Model
+	GuildID        string `gorm:"uniqueIndex"`
+	VoiceChannelID string
+	TextChannelID  string // Where the overview message was sent
+	MessageID      string // The overview message
+	QueueIndex     int
+	Looping        bool
+	Position       time.Duration      // How far into the current song the music was
+	Songs          []MusicSessionSong `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// MusicSessionSong is a song in the queue of a saved music session
+type MusicSessionSong struct {
+	Model
+	MusicSessionID uint `gorm:"index"`
+	QueuePosition  int
+	Source         string // Name of the resolver that found the song
+	YoutubeVideoID string
+	URL            string
+	StreamURL      string // Only kept for local files. Stream URLs from youtube expire
+	Title          string
+	ChannelName    string
+	Thumbnail      string
+	Duration       time.Duration
+	ChannelID      string
+	User           string // Who requested the song
+}
+
+func (MusicSession) TableName() string {
+	return "musicSessions"
+}
+
+func (MusicSessionSong) TableName() string {
+	return "musicSessionSongs"
+}
+
+// Saves the session and replaces its songs in the database
+func (ms *MusicSession) Save() {
+
+	var existing MusicSession
+	if DB.Where("guild_id = ?", ms.GuildID).First(&existing).RowsAffected > 0 {
+		ms.ID = existing.ID
+		ms.CreatedAt = existing.CreatedAt
+		DB.Where("music_session_id = ?", ms.ID).Delete(&MusicSessionSong{})
+	}
+
+	DB.Save(&ms)
+}
+
+// Loads the session for the guild together with its songs. Returns false if there is no session
+func (ms *MusicSession) QueryByGuildID(guildID string) bool {
+
+	result := DB.Preload("Songs", func(db *gorm.DB) *gorm.DB {
+		return db.Order("queue_position asc")
+	}).Where("guild_id = ?", guildID).First(&ms)
+
+	return result.RowsAffected > 0
+}
+
+// Removes the session and its songs from the database
+func (ms *MusicSession) DeleteFromDB() {
+
+	DB.Where("music_session_id = ?", ms.ID).Delete(&MusicSessionSong{})
+	DB.Delete(&ms)
+}
+
+// GetAllMusicSessions returns every saved session without their songs
+func GetAllMusicSessions() []MusicSession {
+	var sessions []MusicSession
+	DB.Find(&sessions)
+	return sessions
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+)
+
+/*
+	The stats shown on the profile and the profile card.
+	The user can set a bio and an accent color for their profile
+*/
+
+// ProfileStats are the stats shown on the profile
+type ProfileStats struct {
+	Level             int
+	LevelXP           uint64 // XP in the current level
+	LevelXPNeeded     uint64 // XP the current level needs
+	NetWorth          uint64
+	Rank              int // By lifetime earnings
+	WorkStreak        uint16
+	DailyStreak       uint16
+	Achievements      int
+	TotalAchievements int
+	Farm              Farm // Has the ID 0 if the user has no farm
+	PlantedPlots      int
+}
+
+// QueryProfileStats queries the stats shown on the profile
+func (u *User) QueryProfileStats(work *Work, daily *Daily) ProfileStats {
+
+	var farm Farm
+	DB.Raw("SELECT * FROM userFarms WHERE userFarms.ID = ?", u.ID).First(&farm)
+
+	var plantedPlots int64
+	DB.Model(&FarmPlot{}).Where("farm_id = ?", u.ID).Count(&plantedPlots)
+
+	xp, needed := u.levelProgress()
+
+	return ProfileStats{
+		Level:             u.GetLevel(),
+		LevelXP:           xp,
+		LevelXPNeeded:     needed,
+		NetWorth:          u.NetWorth(work, &farm),
+		Rank:              u.QueryRank(),
+		WorkStreak:        work.ConsecutiveStreaks,
+		DailyStreak:       daily.ConsecutiveStreaks,
+		Achievements:      len(u.QueryUnlockedAchievements()),
+		TotalAchievements: len(GetAchievements()),
+		Farm:              farm,
+		PlantedPlots:      int(plantedPlots),
+	}
+}
+
+// NetWorth returns what the user owns. The wallet, the tools and the farm plots that were bought
+func (u *User) NetWorth(work *Work, farm *Farm) uint64 {
+	return u.Money + uint64(work.ToolsValue()) + uint64(farm.PlotsValue())
+}
+
+// QueryRank returns the position of the user when everyone is sorted by lifetime earnings. Guild accounts are left out
+func (u *User) QueryRank() int {
+
+	var ahead int64
+	DB.Model(&User{}).Where("lifetime_earnings > ? AND discord_id NOT LIKE ?", u.LifetimeEarnings, guildFarmAccountPrefix+"%").Count(&ahead)
+	return int(ahead) + 1
+}
+
+// ProfileColor returns the accent color of the profile
+func (u *User) ProfileColor() int {
+	if u.AccentColor == 0 {
+		return config.CONFIG.Colors.Neutral
+	}
+	return u.AccentColor
+}
+
+// SetBio sets the bio of the user. An empty bio removes it
+// Returns false if the bio is too long
+func (u *User) SetBio(bio string) bool {
+
+	bio = strings.TrimSpace(bio)
+	if len([]rune(bio)) > config.CONFIG.Profile.MaxBioLength {
+		return false
+	}
+	u.Bio = bio
+	return true
+}
+
+// FarmSummary describes the farm of the user
+func (ps *ProfileStats) FarmSummary() string {
+
+	if ps.Farm.ID == 0 {
+		return "No farm yet"
+	}
+
+	summary := fmt.Sprintf("%d/%d plots planted\n%d crops unlocked\n%d crops harvested", ps.PlantedPlots, ps.Farm.OwnedPlots, ps.Farm.HighestPlantedCropIndex, ps.Farm.TotalHarvests)
+	if ps.Farm.PrestigeLevel > 0 {
+		summary += fmt.Sprintf("\nPrestige %d", ps.Farm.PrestigeLevel)
+	}
+	return summary
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/malm"
+)
+
+/*
+	A streak freeze keeps the work or daily streak when the user waits too long.
+	Each missed streak window uses one freeze. The freezes are only used if the user has enough of them to cover every missed window
+*/
+
+// HasMaxStreakFreezes returns true if the user can't own any more streak freezes
+func (u *User) HasMaxStreakFreezes() bool {
+	return u.StreakFreezes >= config.CONFIG.StreakFreeze.MaxOwned
+}
+
+// BuyStreakFreeze buys a streak freeze. Check the price and the limit first
+// Remember to save the user
+func (u *User) BuyStreakFreeze() {
+	u.DeductMoney(uint64(config.CONFIG.StreakFreeze.Price))
+	u.StreakFreezes++
+}
+
+// useStreakFreezes uses the freezes to keep the streak. Returns false, without using any, if the user does not have enough
+// Remember to save the user
+func (u *User) useStreakFreezes(count int, streakName string) bool {
+
+	if count <= 0 || int(u.StreakFreezes) < count {
+		return false
+	}
+
+	u.StreakFreezes -= uint8(count)
+	malm.Info("%s used %d streak freeze(s) to keep their %s streak", u.DiscordID, count, streakName)
+	return true
+}
+
+// missedStreakWindows returns how many streak windows have passed since the last time the streak was kept
+func missedStreakWindows(since time.Duration, resetHours int) int {
+
+	if resetHours <= 0 || since.Hours() <= float64(resetHours) {
+		return 0
+	}
+	return int(since.Hours() / float64(resetHours))
+}
+
+// StreakFreezeNotice describes the streak freezes that were used. Empty if none were used
+func StreakFreezeNotice(used int) string {
+
+	if used == 0 {
+		return ""
+	}
+
+	wordFormat := "freezes"
+	if used == 1 {
+		wordFormat = "freeze"
+	}
+
+	return fmt.Sprintf("%s Your streak was kept by using %d streak %s!", config.CONFIG.Emojis.StreakFreeze, used, wordFormat)
+}
@@ -0,0 +1,30 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/test"
+)
+
+func TestMissedStreakWindows(t *testing.T) {
+
+	test.Validate(t, missedStreakWindows(time.Hour*10, 24), 0, "No window should be missed within the reset time")
+	test.Validate(t, missedStreakWindows(time.Hour*24, 24), 0, "The last hour of the window should not count as missed")
+	test.Validate(t, missedStreakWindows(time.Hour*25, 24), 1, "One window should be missed right after the reset time")
+	test.Validate(t, missedStreakWindows(time.Hour*49, 24), 2, "Two windows should be missed after twice the reset time")
+	test.Validate(t, missedStreakWindows(time.Hour*100, 0), 0, "A reset time of 0 should never be protected")
+}
+
+func TestUseStreakFreezes(t *testing.T) {
+
+	user := User{StreakFreezes: 2}
+
+	test.Validate(t, user.useStreakFreezes(3, "work"), false, "Freezes should not be used when there are not enough")
+	test.Validate(t, user.StreakFreezes, uint8(2), "No freezes should be used when there are not enough")
+
+	test.Validate(t, user.useStreakFreezes(2, "work"), true, "Freezes should be used when there are enough")
+	test.Validate(t, user.StreakFreezes, uint8(0), "Every freeze should have been used")
+
+	test.Validate(t, user.useStreakFreezes(0, "daily"), false, "Nothing should happen without a missed window")
+}
@@ -2,12 +2,9 @@ package database
 
 import (
 	"fmt"
-	"math"
 	"time"
 
 	"github.com/CarlFlo/DiscordMoneyBot/src/config"
-	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
-	"github.com/bwmarrin/discordgo"
 	"gorm.io/gorm"
 )
 
@@ -16,7 +13,14 @@ type Work struct {
 	LastWorkedAt       time.Time
 	ConsecutiveStreaks uint16
 	Streak             uint16
-	Tools              uint8
+	Tools              []*WorkTool
+	Job                string // Empty for the first job
+	JobExperience      int    // Experience in the current job
+	Shifts             int    // Shifts worked in every job
+
+	ToolsChanged      bool `gorm:"-"` // The tools are saved along with the work when set
+	Promoted          bool `gorm:"-"` // Set when the last shift gave a promotion
+	UsedStreakFreezes int  `gorm:"-"` // Set when streak freezes kept the streak
 }
 
 func (Work) TableName() string {
@@ -29,6 +33,14 @@ func (w *Work) AfterCreate(tx *gorm.DB) error {
 
 // Saves the data to the database
 func (w *Work) Save() {
+
+	// Updates/saves the tools as well
+	if w.ToolsChanged {
+		for _, tool := range w.Tools {
+			tool.Save()
+		}
+	}
+
 	DB.Save(&w)
 }
 
@@ -38,20 +50,20 @@ func (w *Work) GetWorkInfo(u *User) {
 	if w.ID == 0 {
 		w.ID = u.ID
 	}
+	w.QueryTools()
 }
 
 // CanDoWork - Checks if the user can work again
 // Returns true if the user can work and false if they cant
 func (w *Work) CanDoWork() bool {
 
-	since := time.Since(w.LastWorkedAt).Hours()
-	return config.CONFIG.Debug.IgnoreWorkCooldown || since > float64(config.CONFIG.Work.Cooldown)
+	return config.CONFIG.Debug.IgnoreWorkCooldown || time.Since(w.LastWorkedAt) > w.GetJob().Cooldown
 }
 
 // Returns the time the user can work next as a formatted discord string
 // https://hammertime.cyou/
 func (w *Work) CanDoWorkAt() string {
-	nextTime := w.LastWorkedAt.Add(time.Hour * config.CONFIG.Work.Cooldown).Unix()
+	nextTime := w.LastWorkedAt.Add(w.GetJob().Cooldown).Unix()
 	return fmt.Sprintf("<t:%d:R>", nextTime)
 }
 
@@ -61,10 +73,16 @@ func (w *Work) CanDoWorkAt() string {
 // Updates the streak for the user i.e. adding one to the counters
 // and ensuring the streak is not over the max streak
 // and updating the time of the last work
-func (w *Work) StreakPreMsgAction() {
-	if time.Since(w.LastWorkedAt).Hours() > float64(config.CONFIG.Work.StreakResetHours) {
-		w.ConsecutiveStreaks = 0
-		w.Streak = 0
+// and giving the user experience in their job
+// The user's streak freezes are used to keep the streak if they can cover the missed time. Remember to save the user
+func (w *Work) StreakPreMsgAction(user *User) {
+	if missed := missedStreakWindows(time.Since(w.LastWorkedAt), config.CONFIG.Work.StreakResetHours); missed > 0 {
+		if w.ConsecutiveStreaks > 0 && w.CanDoWork() && user.useStreakFreezes(missed, "work") {
+			w.UsedStreakFreezes = missed
+		} else {
+			w.ConsecutiveStreaks = 0
+			w.Streak = 0
+		}
 	}
 
 	if !w.CanDoWork() {
@@ -72,6 +90,7 @@ func (w *Work) StreakPreMsgAction() {
 	}
 
 	w.LastWorkedAt = time.Now()
+	w.addShift()
 
 	w.ConsecutiveStreaks += 1
 	w.Streak += 1
@@ -88,47 +107,3 @@ func (w *Work) StreakPostMsgAction() {
 
 	w.Streak %= uint16(len(config.CONFIG.Work.StreakOutput))
 }
-
-func (w *Work) HasHitMaxToolLimit() bool {
-	return w.Tools >= config.CONFIG.Work.MaxTools
-}
-
-// Returns how much it would cost the user to buy a new tool
-// Returns the amount as an int and formatted string
-func (w *Work) CalcBuyToolPrice() (int, string) {
-
-	multiplier := config.CONFIG.Work.ToolBasePriceMultiplier
-	basePrice := float64(config.CONFIG.Work.ToolBasePrice)
-
-	price := int(basePrice * math.Pow(multiplier, float64(w.Tools)))
-	priceString := utils.HumanReadableNumber(price)
-
-	return price, priceString
-}
-
-func (w *Work) CreateMessageComponents() []discordgo.MessageComponent {
-
-	components := []discordgo.MessageComponent{}
-
-	_, priceString := w.CalcBuyToolPrice()
-
-	if !w.HasHitMaxToolLimit() {
-
-		// Adds each tool present in the config file
-		components = append(components, &discordgo.Button{
-			Label:    fmt.Sprintf("Buy Tool (%s)", priceString),
-			Style:    3, // Green color style
-			Disabled: false,
-			Emoji: discordgo.ComponentEmoji{
-				Name: config.CONFIG.Emojis.ComponentEmojiNames.MoneyBag,
-			},
-			CustomID: "BWT", // 'BWT' is code for 'Buy Work Tool'
-		})
-	}
-
-	if len(components) == 0 {
-		return nil
-	}
-
-	return []discordgo.MessageComponent{discordgo.ActionsRow{Components: components}}
-}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/malm"
+	"gorm.io/gorm"
+)
+
+/*
+	A work shift is saved while the user has a task to answer, since the work cooldown has already been used.
+	If the bot is stopped before the shift ends, the user is paid as if they did not answer in time when it starts again
+*/
+
+type WorkShift struct {
+	Model
+	DiscordID string `gorm:"uniqueIndex"`
+	Income    int    // The income before it is scaled by the result
+}
+
+func (WorkShift) TableName() string {
+	return "workShifts"
+}
+
+// Start saves the shift. A shift the user already had is replaced
+func (ws *WorkShift) Start() {
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("discord_id = ?", ws.DiscordID).Delete(&WorkShift{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(ws).Error
+	})
+
+	if err != nil {
+		malm.Error("Could not save the work shift! %s", err)
+	}
+}
+
+// End deletes the shift
+func (ws *WorkShift) End() {
+	DB.Delete(&WorkShift{}, ws.ID)
+}
+
+// PayOpenWorkShifts pays the shifts that did not end before the bot was stopped
+func PayOpenWorkShifts() {
+
+	var shifts []WorkShift
+	DB.Find(&shifts)
+
+	for _, ws := range shifts {
+
+		var user User
+		user.QueryUserByDiscordID(ws.DiscordID)
+		if user.ID != 0 {
+			user.AddMoney(uint64(float64(ws.Income) * config.CONFIG.Work.MiniGameTimeoutMultiplier))
+			user.Save()
+		}
+
+		ws.End()
+	}
+
+	if len(shifts) > 0 {
+		malm.Info("Paid %d unfinished work shifts", len(shifts))
+	}
+}
@@ -0,0 +1,312 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/bwmarrin/discordgo"
+)
+
+/*
+	Tools give a bonus to the paycheck. Each tool is bought at its first tier and can be upgraded to better tiers.
+	Every shift wears the tools down, and a broken tool gives no bonus until it is repaired.
+	The tools are defined in the config, the user only owns the tier and the durability
+*/
+
+type WorkTool struct {
+	Model
+	WorkID     uint   `gorm:"index"`
+	Name       string // The name of the tool in the config
+	Tier       uint8  // Index of the tier in the config
+	Durability int    // Shifts left before the tool breaks
+}
+
+func (WorkTool) TableName() string {
+	return "userWorkTools"
+}
+
+// Saves the data to the database
+func (wt *WorkTool) Save() {
+	DB.Save(&wt)
+}
+
+type Tool struct {
+	Name  string
+	Emoji string
+	Tiers []ToolTier
+}
+
+type ToolTier struct {
+	Name       string
+	Price      int
+	Bonus      int
+	PayBonus   float64
+	Durability int
+}
+
+// GetTools returns the tools from the config
+func GetTools() []Tool {
+
+	var tools []Tool
+	for _, t := range config.CONFIG.Work.Tools {
+
+		var tiers []ToolTier
+		for _, tier := range t.Tiers {
+			tiers = append(tiers, ToolTier{
+				Name:       tier.Name,
+				Price:      tier.Price,
+				Bonus:      tier.Bonus,
+				PayBonus:   tier.PayBonus,
+				Durability: tier.Durability,
+			})
+		}
+
+		tools = append(tools, Tool{Name: t.Name, Emoji: t.Emoji, Tiers: tiers})
+	}
+	return tools
+}
+
+// tier returns the tier of the tool the user owns. Tiers removed from the config gives the highest remaining tier
+func (wt *WorkTool) tier(tool Tool) ToolTier {
+	if int(wt.Tier) >= len(tool.Tiers) {
+		return tool.Tiers[len(tool.Tiers)-1]
+	}
+	return tool.Tiers[wt.Tier]
+}
+
+// IsBroken returns true if the tool has to be repaired before it gives a bonus again
+func (wt *WorkTool) IsBroken() bool {
+	return wt.Durability <= 0
+}
+
+// Queries the tools the user owns
+func (w *Work) QueryTools() {
+	DB.Raw("SELECT * FROM userWorkTools WHERE userWorkTools.Work_ID = ?", w.ID).Find(&w.Tools)
+}
+
+// ownedTool returns the user's copy of the tool. Nil if the user does not own it
+func (w *Work) ownedTool(tool Tool) *WorkTool {
+	for _, wt := range w.Tools {
+		if strings.EqualFold(wt.Name, tool.Name) {
+			return wt
+		}
+	}
+	return nil
+}
+
+// ToolUpgradePrice returns the price of buying the tool, or upgrading it to the next tier
+// Returns false if the tool has the highest tier
+func (w *Work) ToolUpgradePrice(tool Tool) (int, bool) {
+
+	owned := w.ownedTool(tool)
+	if owned == nil {
+		return tool.Tiers[0].Price, true
+	}
+
+	next := int(owned.Tier) + 1
+	if next >= len(tool.Tiers) {
+		return 0, false
+	}
+	return tool.Tiers[next].Price, true
+}
+
+// UpgradeTool buys the tool, or upgrades it to the next tier. The tool is fully repaired
+// Check the price with ToolUpgradePrice first. Remember to save the work
+func (w *Work) UpgradeTool(tool Tool) ToolTier {
+
+	owned := w.ownedTool(tool)
+	if owned == nil {
+		owned = &WorkTool{WorkID: w.ID, Name: tool.Name}
+		w.Tools = append(w.Tools, owned)
+	} else {
+		owned.Tier++
+	}
+
+	tier := owned.tier(tool)
+	owned.Durability = tier.Durability
+	w.ToolsChanged = true
+
+	return tier
+}
+
+// ToolRepairPrice returns how much it costs to fully repair the tool. 0 if it is not damaged or not owned
+func (w *Work) ToolRepairPrice(tool Tool) int {
+
+	owned := w.ownedTool(tool)
+	if owned == nil {
+		return 0
+	}
+
+	tier := owned.tier(tool)
+	missing := tier.Durability - owned.Durability
+	if missing <= 0 || tier.Durability <= 0 {
+		return 0
+	}
+
+	price := int(float64(tier.Price) * config.CONFIG.Work.ToolRepairCostMultiplier * float64(missing) / float64(tier.Durability))
+	if price < 1 {
+		return 1
+	}
+	return price
+}
+
+// RepairTool fully repairs the tool. Check the price with ToolRepairPrice first
+// Remember to save the work
+func (w *Work) RepairTool(tool Tool) {
+
+	if owned := w.ownedTool(tool); owned != nil {
+		owned.Durability = owned.tier(tool).Durability
+		w.ToolsChanged = true
+	}
+}
+
+// ToolsValue returns what the user has paid for their tools, without the repairs
+func (w *Work) ToolsValue() int {
+
+	value := 0
+	for _, tool := range GetTools() {
+		if owned := w.ownedTool(tool); owned != nil {
+			for tier := 0; tier <= int(owned.Tier) && tier < len(tool.Tiers); tier++ {
+				value += tool.Tiers[tier].Price
+			}
+		}
+	}
+	return value
+}
+
+// usableTiers returns the tiers of the tools that are not broken
+func (w *Work) usableTiers() []ToolTier {
+
+	var tiers []ToolTier
+	for _, tool := range GetTools() {
+		if owned := w.ownedTool(tool); owned != nil && !owned.IsBroken() {
+			tiers = append(tiers, owned.tier(tool))
+		}
+	}
+	return tiers
+}
+
+// ToolBonus returns the amount the tools add to each paycheck
+func (w *Work) ToolBonus() int {
+
+	bonus := 0
+	for _, tier := range w.usableTiers() {
+		bonus += tier.Bonus
+	}
+	return bonus
+}
+
+// ToolPayMultiplier returns how much more the user earns from their tools
+func (w *Work) ToolPayMultiplier() float64 {
+
+	multiplier := 1.0
+	for _, tier := range w.usableTiers() {
+		multiplier += tier.PayBonus
+	}
+	return multiplier
+}
+
+// WearTools wears down the tools that are not broken. Run after the income is calculated, so the last shift of a tool counts
+// Remember to save the work
+func (w *Work) WearTools() {
+
+	for _, wt := range w.Tools {
+		if !wt.IsBroken() {
+			wt.Durability--
+			w.ToolsChanged = true
+		}
+	}
+}
+
+// ToolsDescription lists the tools the user owns
+func (w *Work) ToolsDescription() string {
+
+	var lines []string
+	for _, tool := range GetTools() {
+
+		owned := w.ownedTool(tool)
+		if owned == nil {
+			continue
+		}
+
+		tier := owned.tier(tool)
+
+		var bonuses []string
+		if tier.Bonus > 0 {
+			bonuses = append(bonuses, fmt.Sprintf("+%s %s", utils.HumanReadableNumber(tier.Bonus), config.CONFIG.Economy.Name))
+		}
+		if tier.PayBonus > 0 {
+			bonuses = append(bonuses, fmt.Sprintf("+%.0f%% pay", tier.PayBonus*100))
+		}
+
+		status := fmt.Sprintf("%d/%d durability", owned.Durability, tier.Durability)
+		if owned.IsBroken() {
+			status = fmt.Sprintf("%s **Broken!** Repair it to get the bonus", config.CONFIG.Emojis.Failure)
+		}
+
+		lines = append(lines, fmt.Sprintf("%s **%s** (tier %d/%d) %s\n%s", tool.Emoji, tier.Name, owned.Tier+1, len(tool.Tiers), strings.Join(bonuses, ", "), status))
+	}
+
+	if len(lines) == 0 {
+		return "You have no tools. Buy one below to earn more each shift!"
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CreateMessageComponents creates a row of buttons for each tool. Buying and upgrading is the same button
+func (w *Work) CreateMessageComponents() []discordgo.MessageComponent {
+
+	var rows []discordgo.MessageComponent
+
+	for i, tool := range GetTools() {
+
+		owned := w.ownedTool(tool)
+		price, canUpgrade := w.ToolUpgradePrice(tool)
+
+		var upgradeButton *discordgo.Button
+		if owned == nil {
+			upgradeButton = &discordgo.Button{
+				Label: fmt.Sprintf("Buy %s (%s)", tool.Tiers[0].Name, utils.HumanReadableNumber(price)),
+				Emoji: discordgo.ComponentEmoji{Name: config.CONFIG.Emojis.ComponentEmojiNames.MoneyBag},
+			}
+		} else if canUpgrade {
+			upgradeButton = &discordgo.Button{
+				Label: fmt.Sprintf("Upgrade to %s (%s)", tool.Tiers[owned.Tier+1].Name, utils.HumanReadableNumber(price)),
+				Emoji: discordgo.ComponentEmoji{Name: config.CONFIG.Emojis.ComponentEmojiNames.Upgrade},
+			}
+		} else {
+			upgradeButton = &discordgo.Button{
+				Label:    fmt.Sprintf("%s is fully upgraded", owned.tier(tool).Name),
+				Disabled: true,
+				Emoji:    discordgo.ComponentEmoji{Name: config.CONFIG.Emojis.ComponentEmojiNames.Upgrade},
+			}
+		}
+		upgradeButton.Style = 3                          // Green color style
+		upgradeButton.CustomID = fmt.Sprintf("WTU%d", i) // 'WTU' is code for 'Work Tool Upgrade'
+
+		components := []discordgo.MessageComponent{upgradeButton}
+
+		// Only shown when the tool is damaged
+		if repairPrice := w.ToolRepairPrice(tool); repairPrice > 0 {
+			components = append(components, &discordgo.Button{
+				Label:    fmt.Sprintf("Repair %s (%s)", owned.tier(tool).Name, utils.HumanReadableNumber(repairPrice)),
+				Style:    1, // Default purple
+				Disabled: false,
+				Emoji: discordgo.ComponentEmoji{
+					Name: config.CONFIG.Emojis.ComponentEmojiNames.Repair,
+				},
+				CustomID: fmt.Sprintf("WTR%d", i), // 'WTR' is code for 'Work Tool Repair'
+			})
+		}
+
+		rows = append(rows, discordgo.ActionsRow{Components: components})
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	return rows
+}
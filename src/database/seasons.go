@@ -0,0 +1,181 @@
+package database
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+)
+
+/*
+	The seasons and the weather are the same for everyone.
+	Both are calculated from the time, so they survive restarts and the forecast is always correct.
+
+	The weather at the time of planting decides how fast the crop grows
+	The season at the time of harvesting decides if the crop gives a bonus. Each crop has its preferred seasons
+*/
+
+type Season uint8
+
+const (
+	Spring Season = iota
+	Summer
+	Autumn
+	Winter
+)
+
+var seasons = []Season{Spring, Summer, Autumn, Winter}
+
+func (s Season) String() string {
+	switch s {
+	case Spring:
+		return "Spring"
+	case Summer:
+		return "Summer"
+	case Autumn:
+		return "Autumn"
+	default:
+		return "Winter"
+	}
+}
+
+func (s Season) Emoji() string {
+	switch s {
+	case Spring:
+		return "🌸"
+	case Summer:
+		return "🌞"
+	case Autumn:
+		return "🍂"
+	default:
+		return "❄️"
+	}
+}
+
+// ParseSeason returns the season with the name. False if there is no such season
+func ParseSeason(name string) (Season, bool) {
+	for _, s := range seasons {
+		if strings.EqualFold(s.String(), strings.TrimSpace(name)) {
+			return s, true
+		}
+	}
+	return Spring, false
+}
+
+type Weather struct {
+	Name             string
+	Emoji            string
+	GrowthMultiplier float64 // Crops planted in this weather grow faster when above 1
+	RewardMultiplier float64 // Crops harvested in this weather earn more when above 1
+}
+
+// The weather that can happen during each season. A weather can be listed more than once to make it more common
+var seasonalWeather = map[Season][]Weather{
+	Spring: {weatherSunny, weatherRain, weatherRain, weatherCloudy},
+	Summer: {weatherSunny, weatherSunny, weatherHeatwave, weatherStorm},
+	Autumn: {weatherCloudy, weatherRain, weatherStorm, weatherSunny},
+	Winter: {weatherCloudy, weatherSnow, weatherSnow, weatherSunny},
+}
+
+var (
+	weatherSunny    = Weather{Name: "Sunny", Emoji: "☀️", GrowthMultiplier: 1, RewardMultiplier: 1}
+	weatherCloudy   = Weather{Name: "Cloudy", Emoji: "☁️", GrowthMultiplier: 0.9, RewardMultiplier: 1}
+	weatherRain     = Weather{Name: "Rain", Emoji: "🌧️", GrowthMultiplier: 1.25, RewardMultiplier: 1}
+	weatherHeatwave = Weather{Name: "Heatwave", Emoji: "🔥", GrowthMultiplier: 1.1, RewardMultiplier: 0.9}
+	weatherStorm    = Weather{Name: "Storm", Emoji: "⛈️", GrowthMultiplier: 1, RewardMultiplier: 0.8}
+	weatherSnow     = Weather{Name: "Snow", Emoji: "🌨️", GrowthMultiplier: 0.75, RewardMultiplier: 1.1}
+)
+
+func seasonLength() time.Duration {
+	return time.Hour * 24 * time.Duration(config.CONFIG.Farm.SeasonLengthDays)
+}
+
+func weatherLength() time.Duration {
+	return time.Hour * time.Duration(config.CONFIG.Farm.WeatherChangeHours)
+}
+
+// SeasonAt returns the season at the given time
+func SeasonAt(t time.Time) Season {
+	if seasonLength() <= 0 {
+		return Spring
+	}
+	return seasons[(t.Unix()/int64(seasonLength().Seconds()))%int64(len(seasons))]
+}
+
+// SeasonEndsAt returns when the season at the given time ends
+func SeasonEndsAt(t time.Time) time.Time {
+	if seasonLength() <= 0 {
+		return time.Time{}
+	}
+	return periodStart(t, seasonLength()).Add(seasonLength())
+}
+
+// WeatherAt returns the weather at the given time.
+// The weather is picked from the season's weather with a hash of the time period, so it is the same every time
+func WeatherAt(t time.Time) Weather {
+
+	if weatherLength() <= 0 {
+		return weatherSunny
+	}
+
+	period := t.Unix() / int64(weatherLength().Seconds())
+
+	hash := fnv.New32a()
+	hash.Write([]byte(fmt.Sprintf("weather-%d", period)))
+
+	possible := seasonalWeather[SeasonAt(t)]
+	return possible[hash.Sum32()%uint32(len(possible))]
+}
+
+// periodStart returns when the period containing the time started. The periods are counted from the unix epoch
+func periodStart(t time.Time, length time.Duration) time.Time {
+	seconds := int64(length.Seconds())
+	return time.Unix(t.Unix()/seconds*seconds, 0)
+}
+
+type WeatherForecast struct {
+	From    time.Time
+	Weather Weather
+}
+
+// GetWeatherForecast returns the weather for the next n periods, starting with the current one
+func GetWeatherForecast(from time.Time, n int) []WeatherForecast {
+
+	if weatherLength() <= 0 {
+		return []WeatherForecast{{From: from, Weather: weatherSunny}}
+	}
+
+	var forecast []WeatherForecast
+	start := periodStart(from, weatherLength())
+
+	for i := 0; i < n; i++ {
+		at := start.Add(weatherLength() * time.Duration(i))
+		forecast = append(forecast, WeatherForecast{From: at, Weather: WeatherAt(at)})
+	}
+	return forecast
+}
+
+// SeasonAndWeatherDescription returns the current season, weather and forecast for the farm embed
+func SeasonAndWeatherDescription(now time.Time) string {
+
+	season := SeasonAt(now)
+	description := fmt.Sprintf("%s %s", season.Emoji(), season)
+	if end := SeasonEndsAt(now); !end.IsZero() {
+		description += fmt.Sprintf(" (ends <t:%d:R>)", end.Unix())
+	}
+
+	forecast := GetWeatherForecast(now, 4)
+	description += fmt.Sprintf("\n%s %s", forecast[0].Weather.Emoji, forecast[0].Weather.Name)
+
+	if len(forecast) > 1 {
+		var upcoming []string
+		for _, f := range forecast[1:] {
+			upcoming = append(upcoming, f.Weather.Emoji)
+		}
+		description += fmt.Sprintf(" | Forecast: %s", strings.Join(upcoming, " → "))
+	}
+
+	return description
+}
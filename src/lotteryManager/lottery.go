@@ -0,0 +1,113 @@
+package lotteryManager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/CarlFlo/DiscordMoneyBot/src/bot/context"
+	"github.com/CarlFlo/DiscordMoneyBot/src/config"
+	"github.com/CarlFlo/DiscordMoneyBot/src/database"
+	"github.com/CarlFlo/DiscordMoneyBot/src/utils"
+	"github.com/CarlFlo/malm"
+	"github.com/bwmarrin/discordgo"
+)
+
+// This module is responsible for drawing the lottery.
+// The time of the next draw is saved in the database, so the schedule is kept when the bot is restarted.
+// A draw that was due while the bot was off is drawn on the first check
+
+const checkInterval = time.Minute
+
+var stopper = make(chan interface{})
+
+func Initialize() {
+
+	ticker := time.NewTicker(checkInterval)
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				checkDraw()
+			case <-stopper:
+				ticker.Stop()
+				malm.Info("Lottery manager stopped")
+				return
+			}
+		}
+	}()
+	malm.Info("Lottery manager initialized (draws every %d hours)", config.CONFIG.Lottery.DrawIntervalHours)
+}
+
+func Stop() {
+	stopper <- nil
+}
+
+// checkDraw draws the lottery if it is due
+func checkDraw() {
+
+	// The bot has not connected yet
+	if context.SESSION == nil {
+		return
+	}
+
+	var lottery database.Lottery
+	lottery.QueryLottery()
+
+	if !lottery.IsDue() {
+		return
+	}
+
+	draw, err := lottery.Draw()
+	if err != nil {
+		malm.Error("Could not draw the lottery! %s", err)
+		return
+	}
+
+	if len(draw.Winner) == 0 {
+		malm.Info("The lottery was drawn without any tickets")
+		announce(fmt.Sprintf("No tickets were bought, so the pot of ``%s`` %s is kept for the next draw <t:%d:R>", utils.HumanReadableNumber(lottery.Pot), config.CONFIG.Economy.Name, lottery.DrawAt.Unix()), config.CONFIG.Colors.Neutral)
+		return
+	}
+
+	malm.Info("The lottery was won by %s (%d of %d tickets)", draw.Winner, draw.Tickets, draw.Total)
+
+	announce(fmt.Sprintf("<@%s> won ``%s`` %s with %d of the %d tickets!\n\nThe next draw is <t:%d:R>", draw.Winner, utils.HumanReadableNumber(draw.Prize), config.CONFIG.Economy.Name, draw.Tickets, draw.Total, lottery.DrawAt.Unix()), config.CONFIG.Colors.Success)
+	messageWinner(&draw)
+}
+
+// announce sends the message to the announcement channel, if there is one
+func announce(description string, color int) {
+
+	if len(config.CONFIG.Lottery.AnnouncementChannel) == 0 {
+		return
+	}
+
+	if _, err := context.SESSION.ChannelMessageSendEmbed(config.CONFIG.Lottery.AnnouncementChannel, &discordgo.MessageEmbed{
+		Type:        discordgo.EmbedTypeRich,
+		Title:       ":tickets: Lottery draw",
+		Description: description,
+		Color:       color,
+	}); err != nil {
+		malm.Error("Could not announce the lottery draw! %s", err)
+	}
+}
+
+// messageWinner tells the winner that they won with a direct message
+func messageWinner(draw *database.LotteryDraw) {
+
+	channel, err := context.SESSION.UserChannelCreate(draw.Winner)
+	if err != nil {
+		malm.Error("Could not message the lottery winner! %s", err)
+		return
+	}
+
+	if _, err := context.SESSION.ChannelMessageSendEmbed(channel.ID, &discordgo.MessageEmbed{
+		Type:        discordgo.EmbedTypeRich,
+		Title:       ":tickets: You won the lottery!",
+		Description: fmt.Sprintf("Your %d tickets won the pot of ``%s`` %s!", draw.Tickets, utils.HumanReadableNumber(draw.Prize), config.CONFIG.Economy.Name),
+		Color:       config.CONFIG.Colors.Success,
+	}); err != nil {
+		malm.Error("Could not message the lottery winner! %s", err)
+	}
+}
@@ -58,3 +58,26 @@ func FindVoiceChannel(userID string) string {
 	}
 	return ""
 }
+
+// CountHumansInVoiceChannel returns the number of users in the voice channel that are not bots
+func CountHumansInVoiceChannel(guildID, channelID string) int {
+
+	guild, err := context.SESSION.State.Guild(guildID)
+	if err != nil {
+		return 0
+	}
+
+	count := 0
+	for _, v := range guild.VoiceStates {
+		if v.ChannelID != channelID || v.UserID == context.SESSION.State.User.ID {
+			continue
+		}
+
+		// Users not in the cache are counted as humans
+		if member, err := context.SESSION.State.Member(guildID, v.UserID); err == nil && member.User != nil && member.User.Bot {
+			continue
+		}
+		count++
+	}
+	return count
+}